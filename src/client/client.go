@@ -2,18 +2,37 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"time"
 
-	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	"github.com/golang-jwt/jwt/v5"
+
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	"github.com/lokker96/grpc_project/infrastructure/tlsconfig"
+	"github.com/lokker96/grpc_project/infrastructure/tracing"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
-	// Create new connection to localhost with insecure credentials for development purposes
-	conn, err := grpc.NewClient("localhost:9001", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Every call is traced, the trace context is sent to the server with the W3C traceparent header
+	tracerProvider := newTracerProvider()
+	defer tracerProvider.Shutdown(context.Background())
+
+	// Create new connection to localhost, plaintext unless a CA is configured
+	conn, err := grpc.NewClient("localhost:9001",
+		grpc.WithTransportCredentials(transportCredentials()),
+		grpc.WithStatsHandler(tracing.ClientHandler(tracerProvider)),
+	)
 	if err != nil {
 		log.Fatal("failed to connect to grpc server")
 	}
@@ -21,15 +40,22 @@ func main() {
 	// defer closing connection for later
 	defer conn.Close()
 
-	// make new client for explore gRPC service calls
-	client := ep.NewExploreServiceClient(conn)
+	// make new client for explore gRPC service calls, the v2 API takes the user IDs as numbers
+	client := epv2.NewExploreServiceClient(conn)
 
 	// create new context with 1 second timeout which should be plenty for this exercise
-	ctx, _ := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// All the calls below belong to the same trace
+	ctx, span := tracerProvider.Tracer("explore-client").Start(ctx, "client")
+	defer span.End()
+
+	fmt.Println("Trace ID: ", span.SpanContext().TraceID())
 
 	// Check how many users like user id 1
-	count, err := client.CountLikedYou(ctx, &ep.CountLikedYouRequest{
-		RecipientUserId: "1",
+	count, err := client.CountLikedYou(asUser(ctx, 1), &epv2.CountLikedYouRequest{
+		RecipientUserId: 1,
 	})
 	if err != nil {
 		log.Fatal("error calling function CountLikedYou: %w", err)
@@ -37,9 +63,13 @@ func main() {
 
 	fmt.Println("CountLikedYou - RecipientUserId: 1, Count: ", count.Count)
 
+	for _, countByType := range count.CountsByType {
+		fmt.Println("CountLikedYou - RecipientUserId: 1, Type: ", countByType.DecisionType, ", Count: ", countByType.Count)
+	}
+
 	// Check how many new users like user id 1
-	listNewLikedYouResponse, err := client.ListNewLikedYou(ctx, &ep.ListLikedYouRequest{
-		RecipientUserId: "1",
+	listNewLikedYouResponse, err := client.ListNewLikedYou(asUser(ctx, 1), &epv2.ListLikedYouRequest{
+		RecipientUserId: 1,
 	})
 	if err != nil {
 		log.Fatal("error calling function ListNewLikedYou: %w", err)
@@ -52,39 +82,49 @@ func main() {
 	}
 	fmt.Printf("\n")
 
-	// Check how many users like user id 1
-	listLikedYouResponse, err := client.ListLikedYou(ctx, &ep.ListLikedYouRequest{
-		RecipientUserId: "1",
-	})
-	if err != nil {
-		log.Fatal("error calling function ListLikedYou: %w", err)
-	}
-
+	// Check how many users like user id 1, following the pagination tokens until the last page
 	fmt.Println("ListLikedYou - RecipientUserId: 1, ids: ")
-	for _, like := range listLikedYouResponse.Likers {
-		fmt.Print(like.ActorId, " - ", like.UnixTimestamp)
-		fmt.Printf("\n")
+
+	var paginationToken *string
+	for {
+		listLikedYouResponse, err := client.ListLikedYou(asUser(ctx, 1), &epv2.ListLikedYouRequest{
+			RecipientUserId: 1,
+			PaginationToken: paginationToken,
+		})
+		if err != nil {
+			log.Fatal("error calling function ListLikedYou: %w", err)
+		}
+
+		for _, like := range listLikedYouResponse.Likers {
+			fmt.Print(like.ActorId, " - ", like.UnixTimestamp)
+			fmt.Printf("\n")
+		}
+
+		paginationToken = listLikedYouResponse.NextPaginationToken
+		if paginationToken == nil {
+			break
+		}
 	}
 	fmt.Printf("\n")
 
-	// Put decisions user id 3 likes user id 1
-	putDecisionResponse, err := client.PutDecision(ctx, &ep.PutDecisionRequest{
-		ActorUserId:     "3",
-		RecipientUserId: "1",
-		LikedRecipient:  true,
+	// Put decisions user id 3 super likes user id 1
+	putDecisionResponse, err := client.PutDecision(asUser(ctx, 3), &epv2.PutDecisionRequest{
+		ActorUserId:     3,
+		RecipientUserId: 1,
+		DecisionType:    epv2.DecisionType_DECISION_TYPE_SUPER_LIKE,
 	})
 	if err != nil {
 		log.Fatal("error calling function ListLikedYou: %w", err)
 	}
 
-	fmt.Println("\nActorId: 3 likes RecipientUserId: 1")
+	fmt.Println("\nActorId: 3 super likes RecipientUserId: 1")
 	fmt.Println("Mutual Like - ActorId: 3 and RecipientUserId: 1, response: ", putDecisionResponse.MutualLikes)
 
 	// Test that we can alter the decisions for user id 3 when he does not like user id 1
-	putDecisionResponse, err = client.PutDecision(ctx, &ep.PutDecisionRequest{
-		ActorUserId:     "3",
-		RecipientUserId: "1",
-		LikedRecipient:  false,
+	putDecisionResponse, err = client.PutDecision(asUser(ctx, 3), &epv2.PutDecisionRequest{
+		ActorUserId:     3,
+		RecipientUserId: 1,
+		DecisionType:    epv2.DecisionType_DECISION_TYPE_PASS,
 	})
 	if err != nil {
 		log.Fatal("error calling function ListLikedYou: %w", err)
@@ -93,12 +133,23 @@ func main() {
 	fmt.Println("\nActorId: 3 does not likes RecipientUserId: 1")
 	fmt.Println("Mutual Like - ActorId: 3 and RecipientUserId: 1, response: ", putDecisionResponse.MutualLikes)
 
+	// The pass was a mistake, user id 3 undoes it and the super like is back
+	undoLastDecisionResponse, err := client.UndoLastDecision(asUser(ctx, 3), &epv2.UndoLastDecisionRequest{
+		ActorUserId: 3,
+	})
+	if err != nil {
+		log.Fatal("error calling function UndoLastDecision: %w", err)
+	}
+
+	fmt.Println("\nActorId: 3 undoes its last decision on RecipientUserId:", undoLastDecisionResponse.RecipientUserId)
+	fmt.Println("Undone:", undoLastDecisionResponse.UndoneDecisionType, "restored:", undoLastDecisionResponse.RestoredDecisionType)
+
 	// Test that we can alter the decisions for user id 1 when he does not like user id 2
 	// and that we get the mutual like equal to true
-	putDecisionResponse, err = client.PutDecision(ctx, &ep.PutDecisionRequest{
-		ActorUserId:     "1",
-		RecipientUserId: "2",
-		LikedRecipient:  true,
+	putDecisionResponse, err = client.PutDecision(asUser(ctx, 1), &epv2.PutDecisionRequest{
+		ActorUserId:     1,
+		RecipientUserId: 2,
+		DecisionType:    epv2.DecisionType_DECISION_TYPE_LIKE,
 	})
 	if err != nil {
 		log.Fatal("error calling function ListLikedYou: %w", err)
@@ -108,8 +159,8 @@ func main() {
 	fmt.Println("Mutual Like - ActorId: 1 and RecipientUserId: 2, response: ", putDecisionResponse.MutualLikes)
 
 	// Check how many new users like user id 4
-	listNewLikedYouResponse, err = client.ListNewLikedYou(ctx, &ep.ListLikedYouRequest{
-		RecipientUserId: "4",
+	listNewLikedYouResponse, err = client.ListNewLikedYou(asUser(ctx, 4), &epv2.ListLikedYouRequest{
+		RecipientUserId: 4,
 	})
 	if err != nil {
 		log.Fatal("error calling function ListNewLikedYou: %w", err)
@@ -121,4 +172,122 @@ func main() {
 		fmt.Printf("\n")
 	}
 	fmt.Printf("\n")
+
+	// List the matches of user id 1, user id 2 has been liked back above
+	listMatchesResponse, err := client.ListMatches(asUser(ctx, 1), &epv2.ListMatchesRequest{
+		UserId: 1,
+	})
+	if err != nil {
+		log.Fatal("error calling function ListMatches: %w", err)
+	}
+
+	fmt.Println("ListMatches - UserId: 1, ids: ")
+	for _, match := range listMatchesResponse.Matches {
+		fmt.Print(match.UserId, " - ", match.MatchedAtUnixTimestamp)
+		fmt.Printf("\n")
+	}
+	fmt.Printf("\n")
+}
+
+// Appends the spans to EXPLORER_CLIENT_TRACE_FILE when it is set, the trace context is propagated either way
+// so the spans of the server can be found with the trace ID.
+func newTracerProvider() *sdktrace.TracerProvider {
+	var exporter sdktrace.SpanExporter
+
+	if traceFile := os.Getenv("EXPLORER_CLIENT_TRACE_FILE"); traceFile != "" {
+		file, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			log.Fatal("error opening the trace file: ", err)
+		}
+
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			log.Fatal("error creating the trace exporter: ", err)
+		}
+	}
+
+	tracerProvider, err := tracing.NewTracerProvider("explore-client", exporter, 1)
+	if err != nil {
+		log.Fatal("error creating the tracer provider: ", err)
+	}
+
+	return tracerProvider
+}
+
+// Uses TLS when EXPLORER_CLIENT_TLS_CA_FILE is set, and mutual TLS when EXPLORER_CLIENT_TLS_CERT_FILE and
+// EXPLORER_CLIENT_TLS_KEY_FILE are set too. The certificates of 'server dev-certs' fit, i.e. with the files in ./certs:
+//
+//	EXPLORER_CLIENT_TLS_CA_FILE=certs/ca.crt EXPLORER_CLIENT_TLS_CERT_FILE=certs/client.crt EXPLORER_CLIENT_TLS_KEY_FILE=certs/client.key
+func transportCredentials() credentials.TransportCredentials {
+	caFile := os.Getenv("EXPLORER_CLIENT_TLS_CA_FILE")
+	if caFile == "" {
+		return insecure.NewCredentials()
+	}
+
+	tlsConfig, err := tlsconfig.NewClientConfig(tlsconfig.ClientConfig{
+		CAFile:         caFile,
+		CertFile:       os.Getenv("EXPLORER_CLIENT_TLS_CERT_FILE"),
+		KeyFile:        os.Getenv("EXPLORER_CLIENT_TLS_KEY_FILE"),
+		ServerName:     os.Getenv("EXPLORER_CLIENT_TLS_SERVER_NAME"),
+		ReloadInterval: time.Minute,
+	})
+	if err != nil {
+		log.Fatal("error loading the tls configuration: ", err)
+	}
+
+	return credentials.NewTLS(tlsConfig)
+}
+
+// Adds a bearer token of the user to the outgoing metadata. The tokens are signed with the development key
+// of the compose stack, set EXPLORER_CLIENT_JWKS_FILE to use another JWKS file with a HS256 key.
+func asUser(ctx context.Context, userID int) context.Context {
+	jwksFile := os.Getenv("EXPLORER_CLIENT_JWKS_FILE")
+	if jwksFile == "" {
+		jwksFile = "../../secrets/jwks.json"
+	}
+
+	data, err := os.ReadFile(jwksFile)
+	if err != nil {
+		log.Fatal("error reading the jwks file: ", err)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			K       string `json:"k"`
+		} `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		log.Fatal("error parsing the jwks file: ", err)
+	}
+
+	for _, key := range jwks.Keys {
+		if key.KeyType != "oct" {
+			continue
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(key.K)
+		if err != nil {
+			log.Fatal("error decoding the jwks key: ", err)
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		token.Header["kid"] = key.KeyID
+
+		signed, err := token.SignedString(secret)
+		if err != nil {
+			log.Fatal("error signing the token: ", err)
+		}
+
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+signed)
+	}
+
+	log.Fatal("no HS256 key found in the jwks file")
+	return nil
 }
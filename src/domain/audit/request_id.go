@@ -0,0 +1,19 @@
+// Package audit carries the metadata of a call that is recorded together with the changes the call makes
+package audit
+
+import (
+	"context"
+)
+
+type requestIDKey struct{}
+
+// Returns a copy of the context carrying the request ID of the call
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// Returns the request ID of the call, empty outside of a call
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
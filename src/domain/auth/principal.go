@@ -0,0 +1,48 @@
+// Package auth describes the authenticated caller of a request
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+type Role string
+
+const (
+	RoleService Role = "service" // Another backend acting on behalf of the users
+	RoleAdmin   Role = "admin"
+)
+
+// The authenticated caller, added to the context by the authentication interceptor
+type Principal struct {
+	Subject string        // Subject of the token, the user id for the users
+	UserID  entity.UserID // 0 when the subject is not a user, i.e. a service
+	Roles   []Role
+}
+
+func (p *Principal) HasRole(role Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// True if the principal can read or write the data of the user: the user itself, a service or an admin
+func (p *Principal) CanActAs(userID entity.UserID) bool {
+	if p.HasRole(RoleService) || p.HasRole(RoleAdmin) {
+		return true
+	}
+
+	return p.UserID != 0 && p.UserID == userID
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// A user blocking another one. The decisions between the two users are ignored in both directions
+// while the block exists, whoever blocked whom.
+type Block struct {
+	BlockerID UserID    `gorm:"primaryKey;autoIncrement:false"` // User who blocked
+	BlockedID UserID    `gorm:"primaryKey;autoIncrement:false"` // User who has been blocked
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Block) TableName() string {
+	return "blocks"
+}
@@ -4,17 +4,23 @@ import (
 	"time"
 )
 
+// The table and its indexes are created by the migrations in 'infrastructure/persistence/postgres/migration/sql'
 type Decision struct {
-	ID          uint      `gorm:"primaryKey;autoIncrement"`
-	AuthorID    uint      // Author who made the decision
-	RecipientID uint      // Profile that was presented to the author
-	Liked       bool      // True if liked, false if not. This ideally would be an enum with types PASS and LIKE
+	ID          int64  `gorm:"primaryKey;autoIncrement"`
+	AuthorID    UserID // Author who made the decision, unique together with the recipient
+	RecipientID UserID // Profile that was presented to the author
+	Type        DecisionType
 	Author      User      // gorm uses the author_id to fill this structure with the relational data
 	Recipient   User      // gorm uses the profile_id to fill this structure with the relational data
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"` // Used together with the ID as the pagination cursor
 }
 
 func (Decision) TableName() string {
 	return "decisions"
 }
+
+// A super like and a like are both likes, the super likes are notified as such and rank higher in the liker lists
+func (d Decision) IsLike() bool {
+	return d.Type.IsLike()
+}
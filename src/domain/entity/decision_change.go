@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+// A change made by an upsert of a decision, with the state of the decision before it so the change
+// can be undone exactly. Every upsert adds a change, even the ones leaving the type as it was.
+type DecisionChange struct {
+	ID                int64  `gorm:"primaryKey;autoIncrement"`
+	AuthorID          UserID // Author of the decision, the changes are undone from the most recent of the author
+	RecipientID       UserID
+	Type              DecisionType  // Type set by the change
+	PreviousType      *DecisionType // Type before the change, nil when the change created the decision
+	PreviousUpdatedAt *time.Time    // Update date before the change, nil when the change created the decision
+	ChangedAt         time.Time     // Update date set by the change
+	UndoneAt          *time.Time    // Set once the change has been undone, it can't be undone twice
+}
+
+func (DecisionChange) TableName() string {
+	return "decision_history"
+}
+
+// True when the change turned a pass, or no decision at all, into a like
+func (c DecisionChange) IsNewLike() bool {
+	return c.Type.IsLike() && (c.PreviousType == nil || !c.PreviousType.IsLike())
+}
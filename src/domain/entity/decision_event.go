@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+type DecisionEventCause string
+
+const (
+	DecisionEventCausePut  DecisionEventCause = "put"  // The author made a decision
+	DecisionEventCauseUndo DecisionEventCause = "undo" // The author undid its last decision
+)
+
+// Audit record of a change of a decision. The events are only ever appended, in the same transaction as the
+// change, they are only deleted together with an erased user.
+type DecisionEvent struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement"`
+	AuthorID    UserID // Author of the decision, who made the change
+	RecipientID UserID
+	OldType     *DecisionType // nil when the change created the decision
+	NewType     *DecisionType // nil when the change removed the decision
+	Cause       DecisionEventCause
+	RequestID   string    // Request ID of the call that made the change, empty outside of a call
+	OccurredAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (DecisionEvent) TableName() string {
+	return "decision_events"
+}
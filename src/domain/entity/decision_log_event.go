@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+)
+
+type DecisionLogEventType string
+
+const (
+	DecisionLogEventDecisionMade    DecisionLogEventType = "decision_made"    // The author decided on the recipient for the first time
+	DecisionLogEventDecisionChanged DecisionLogEventType = "decision_changed" // The decision has been changed by a put or an undo, or removed by an undo
+	DecisionLogEventMatchCreated    DecisionLogEventType = "match_created"    // The author and the recipient like each other
+	DecisionLogEventUserDeactivated DecisionLogEventType = "user_deactivated" // The author deactivated its account, its likes are hidden
+	DecisionLogEventUserDeleted     DecisionLogEventType = "user_deleted"     // The author deleted its account, its likes are hidden
+	DecisionLogEventUserBlocked     DecisionLogEventType = "user_blocked"     // The author blocked the recipient, their likes are hidden from each other
+	DecisionLogEventUserUnblocked   DecisionLogEventType = "user_unblocked"   // The author unblocked the recipient
+)
+
+// Event of the decision log, the source of the read projections. The log is append-only: every change of
+// a decision, of the activity of a user or of a block appends an event in the same transaction, the events of
+// a pair of users are committed in order. Unlike the decision events it is not an audit trail, it holds the state
+// of the decision after the event.
+type DecisionLogEvent struct {
+	ID                int64 `gorm:"primaryKey;autoIncrement"`
+	Type              DecisionLogEventType
+	AuthorID          UserID        // Author of the decision, for a match the user whose like made it, for a block the blocker
+	RecipientID       UserID        // The author itself for a deactivation or a deletion
+	DecisionID        int64         // 0 for a match
+	NewType           *DecisionType // Type of the decision after the event, nil when it has been removed and for a match
+	DecisionUpdatedAt *time.Time    // Update date of the decision after the event, nil when it has been removed and for a match
+	OccurredAt        time.Time
+}
+
+func (DecisionLogEvent) TableName() string {
+	return "decision_log"
+}
+
+// True for the events of the users and the blocks, they only hide likes and aren't published
+func (e DecisionLogEvent) IsUserEvent() bool {
+	switch e.Type {
+	case DecisionLogEventUserDeactivated, DecisionLogEventUserDeleted, DecisionLogEventUserBlocked, DecisionLogEventUserUnblocked:
+		return true
+	default:
+		return false
+	}
+}
+
+// The decision after the event, nil when the event removed it or isn't about a decision
+func (e DecisionLogEvent) Decision() *Decision {
+	if e.Type == DecisionLogEventMatchCreated || e.IsUserEvent() || e.NewType == nil {
+		return nil
+	}
+
+	return &Decision{
+		ID:          e.DecisionID,
+		AuthorID:    e.AuthorID,
+		RecipientID: e.RecipientID,
+		Type:        *e.NewType,
+		UpdatedAt:   *e.DecisionUpdatedAt,
+	}
+}
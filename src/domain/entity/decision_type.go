@@ -0,0 +1,26 @@
+package entity
+
+// Decision of an author on a recipient. The values are the ones stored in the type column of the decisions table.
+type DecisionType string
+
+const (
+	DecisionTypePass      DecisionType = "pass"
+	DecisionTypeLike      DecisionType = "like"
+	DecisionTypeSuperLike DecisionType = "super_like"
+)
+
+// Every type that counts as a like, in the order of their ranking
+var LikeDecisionTypes = []DecisionType{DecisionTypeSuperLike, DecisionTypeLike}
+
+func (t DecisionType) IsLike() bool {
+	return t == DecisionTypeLike || t == DecisionTypeSuperLike
+}
+
+// Position of the type in the liker lists, the higher the rank the higher in the list
+func (t DecisionType) Rank() int {
+	if t == DecisionTypeSuperLike {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,11 @@
+package entity
+
+import (
+	"time"
+)
+
+// A match is not stored in its own table, it is read from the two liked decisions of a pair of users
+type Match struct {
+	UserID    UserID    // User who has been liked back
+	MatchedAt time.Time // When the latest of the two likes was made
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Event of the transactional outbox, written in the same transaction as the decision log event it copies and
+// deleted once a relay has published it. The events of a pair of users are published in the order of their IDs.
+type OutboxEvent struct {
+	ID            int64 `gorm:"primaryKey;autoIncrement"`
+	Type          DecisionLogEventType
+	PairKey       string // Both users of the event, see PairKey
+	AuthorID      UserID // Author of the decision, for a match the user whose like made it
+	RecipientID   UserID
+	DecisionType  *DecisionType // Type of the decision after the event, nil when it has been removed and for a match
+	RequestID     string        // Request ID of the call that made the change, empty outside of a call
+	OccurredAt    time.Time
+	Attempts      int        // Failed attempts to publish it
+	NextAttemptAt time.Time  // The event isn't published before, it is pushed back after every failed attempt
+	ClaimedUntil  *time.Time // A relay is publishing the event until then
+	LastError     string     // Error of the last failed attempt
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// Same key whatever the order of the users, i.e. "3:7" for the users 3 and 7
+func PairKey(userID UserID, otherUserID UserID) string {
+	return fmt.Sprintf("%d:%d", min(userID, otherUserID), max(userID, otherUserID))
+}
+
+// The outbox event copying the decision log event
+func NewOutboxEvent(logEvent DecisionLogEvent, requestID string) OutboxEvent {
+	return OutboxEvent{
+		Type:          logEvent.Type,
+		PairKey:       PairKey(logEvent.AuthorID, logEvent.RecipientID),
+		AuthorID:      logEvent.AuthorID,
+		RecipientID:   logEvent.RecipientID,
+		DecisionType:  logEvent.NewType,
+		RequestID:     requestID,
+		OccurredAt:    logEvent.OccurredAt,
+		NextAttemptAt: logEvent.OccurredAt,
+	}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam                 ReportReason = "spam"
+	ReportReasonFakeProfile          ReportReason = "fake_profile"
+	ReportReasonInappropriateContent ReportReason = "inappropriate_content"
+	ReportReasonHarassment           ReportReason = "harassment"
+	ReportReasonOther                ReportReason = "other"
+)
+
+// A user reported to the moderation team, reporting doesn't block the user
+type Report struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	ReporterID UserID // User who reported
+	ReportedID UserID // User who has been reported
+	Reason     ReportReason
+	Details    string    // Free text written by the reporter, may be empty
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
@@ -5,11 +5,18 @@ import (
 )
 
 type User struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID            UserID     `gorm:"primaryKey;autoIncrement"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime"`
+	DeactivatedAt *time.Time // Set when the account is deactivated, its likes are hidden from the other users
+	DeletedAt     *time.Time // Set when the account is deleted, a deleted user is not found anymore
 }
 
 func (User) TableName() string {
 	return "users"
 }
+
+// A deactivated user can't make decisions or be decided on, its likes aren't listed nor counted
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+)
+
+// Audit record of an erased user. It proves that an erasure happened without keeping any personal data,
+// so neither the user id nor the subject of the caller are stored.
+type UserErasure struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement"`
+	RequestedBy     string    // Role of the caller: "user" when the users erase themselves, "service" or "admin" otherwise
+	DecisionsErased int64     // Decisions authored or received by the user that have been deleted
+	ErasedAt        time.Time `gorm:"autoCreateTime"`
+}
+
+func (UserErasure) TableName() string {
+	return "user_erasures"
+}
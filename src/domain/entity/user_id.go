@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Returned when a user ID is zero, negative or not a number
+var ErrInvalidUserID = errors.New("must be a positive user id")
+
+// Identifier of a user, stored as a BIGINT and always positive once validated
+type UserID int64
+
+// Parses a user ID sent as a decimal string, i.e. by the v1 API
+func ParseUserID(value string) (UserID, error) {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidUserID
+	}
+
+	return NewUserID(parsed)
+}
+
+// Validates a user ID sent as a number, i.e. by the v2 API
+func NewUserID(value int64) (UserID, error) {
+	if value <= 0 {
+		return 0, ErrInvalidUserID
+	}
+
+	return UserID(value), nil
+}
+
+func (id UserID) Int64() int64 {
+	return int64(id)
+}
+
+func (id UserID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func Test_ParseUserID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    UserID
+		wantErr error
+	}{
+		{name: "valid id", value: "42", want: 42},
+		{name: "largest id", value: "9223372036854775807", want: 9223372036854775807},
+		{name: "zero", value: "0", wantErr: ErrInvalidUserID},
+		{name: "negative", value: "-1", wantErr: ErrInvalidUserID},
+		{name: "overflow", value: "9223372036854775808", wantErr: ErrInvalidUserID},
+		{name: "not a number", value: "abc", wantErr: ErrInvalidUserID},
+		{name: "empty", value: "", wantErr: ErrInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUserID(tt.value)
+
+			assert.Equal(t, errors.Is(err, tt.wantErr), true)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func Test_UserID_String(t *testing.T) {
+	assert.Equal(t, UserID(42).String(), "42")
+}
@@ -0,0 +1,29 @@
+package error
+
+// Wraps the errors returned by the database so they can be told apart from the business errors
+type DatabaseErr struct {
+	err         error
+	unavailable bool
+}
+
+func NewDatabaseErr(err error) error {
+	return DatabaseErr{err: err}
+}
+
+// Returned when the database can't be reached or doesn't answer in time, the call can be retried later
+func NewDatabaseUnavailableErr(err error) error {
+	return DatabaseErr{err: err, unavailable: true}
+}
+
+func (e DatabaseErr) Error() string {
+	return "error, database failure: " + e.err.Error()
+}
+
+func (e DatabaseErr) Unwrap() error {
+	return e.err
+}
+
+// Tells if the failure comes from the connection to the database rather than from the query
+func (e DatabaseErr) Unavailable() bool {
+	return e.unavailable
+}
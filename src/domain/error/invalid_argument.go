@@ -0,0 +1,20 @@
+package error
+
+import "fmt"
+
+// Returned when a field of a request is not valid, the field name matches the proto field name
+type InvalidArgumentErr struct {
+	Field       string
+	Description string
+}
+
+func NewInvalidArgumentErr(field string, description string) error {
+	return InvalidArgumentErr{
+		Field:       field,
+		Description: description,
+	}
+}
+
+func (e InvalidArgumentErr) Error() string {
+	return fmt.Sprintf("error, invalid %s: %s", e.Field, e.Description)
+}
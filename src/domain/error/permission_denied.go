@@ -0,0 +1,12 @@
+package error
+
+// Returned when the caller is authenticated but not allowed to access the data of another user
+type PermissionDeniedErr struct{}
+
+func NewPermissionDeniedErr() error {
+	return PermissionDeniedErr{}
+}
+
+func (e PermissionDeniedErr) Error() string {
+	return "error, permission denied"
+}
@@ -0,0 +1,12 @@
+package error
+
+// Returned when a stream can't be resumed because the events after the cursor are not retained anymore
+type ResumeCursorExpiredErr struct{}
+
+func NewResumeCursorExpiredErr() error {
+	return ResumeCursorExpiredErr{}
+}
+
+func (e ResumeCursorExpiredErr) Error() string {
+	return "error, resume token has expired"
+}
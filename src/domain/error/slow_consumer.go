@@ -0,0 +1,12 @@
+package error
+
+// Returned when a subscriber doesn't consume its events fast enough and has been dropped
+type SlowConsumerErr struct{}
+
+func NewSlowConsumerErr() error {
+	return SlowConsumerErr{}
+}
+
+func (e SlowConsumerErr) Error() string {
+	return "error, subscriber is too slow to consume its events"
+}
@@ -0,0 +1,14 @@
+package error
+
+// Returned when the caller of a request is unknown, i.e. without a valid token
+type UnauthenticatedErr struct {
+	Reason string
+}
+
+func NewUnauthenticatedErr(reason string) error {
+	return UnauthenticatedErr{Reason: reason}
+}
+
+func (e UnauthenticatedErr) Error() string {
+	return "error, unauthenticated: " + e.Reason
+}
@@ -0,0 +1,14 @@
+package error
+
+// Returned when a component of the service is shutting down or not running
+type UnavailableErr struct {
+	Reason string
+}
+
+func NewUnavailableErr(reason string) error {
+	return UnavailableErr{Reason: reason}
+}
+
+func (e UnavailableErr) Error() string {
+	return "error, unavailable: " + e.Reason
+}
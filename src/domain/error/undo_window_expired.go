@@ -0,0 +1,12 @@
+package error
+
+// Returned when the last decision of a user is too old to be undone
+type UndoWindowExpiredErr struct{}
+
+func NewUndoWindowExpiredErr() error {
+	return UndoWindowExpiredErr{}
+}
+
+func (e UndoWindowExpiredErr) Error() string {
+	return "error, the last decision can't be undone anymore"
+}
@@ -0,0 +1,12 @@
+package error
+
+// Returned when a request needs an active user and the user has deactivated its account
+type UserDeactivatedErr struct{}
+
+func NewUserDeactivatedErr() error {
+	return UserDeactivatedErr{}
+}
+
+func (e UserDeactivatedErr) Error() string {
+	return "error, user is deactivated"
+}
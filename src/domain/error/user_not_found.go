@@ -0,0 +1,11 @@
+package error
+
+type UserNotFoundErr struct{}
+
+func NewUserNotFoundErr() error {
+	return UserNotFoundErr{}
+}
+
+func (e UserNotFoundErr) Error() string {
+	return "error, user not found"
+}
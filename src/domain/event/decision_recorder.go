@@ -0,0 +1,12 @@
+package event
+
+import (
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+// Records the decisions that changed something, i.e. to count the likes, passes and matches created.
+// Recording must never block the caller.
+type DecisionRecorder interface {
+	// Called once per new like, super like or pass, matched tells if the like created a match
+	RecordDecision(decisionType entity.DecisionType, matched bool)
+}
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Message published for every event of the outbox: a decision made, a decision changed or removed, or a match.
+// The delivery is at least once, the consumers dedupe on the ID. The messages of a pair of users are published
+// in order, a message is only published once the previous one of its pair has been.
+type DecisionMessage struct {
+	ID           int64     `json:"id"`
+	Type         string    `json:"type"`      // "decision_made", "decision_changed" or "match_created"
+	PairKey      string    `json:"pair_key"`  // Both users, i.e. "3:7", the ordering key
+	AuthorID     int64     `json:"author_id"` // For a match the user whose like made it
+	RecipientID  int64     `json:"recipient_id"`
+	DecisionType string    `json:"decision_type,omitempty"` // "pass", "like" or "super_like", empty when the decision has been removed and for a match
+	RequestID    string    `json:"request_id,omitempty"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// Publishes the decision messages to the downstream consumers (i.e. notifications, analytics or chat). An error
+// means the message may not have been published, it is published again later.
+type EventPublisher interface {
+	Publish(ctx context.Context, message DecisionMessage) error
+}
@@ -0,0 +1,57 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+type LikeEventType int
+
+const (
+	LikeEventTypeLike      LikeEventType = iota + 1 // The actor liked the recipient
+	LikeEventTypeMatch                              // The actor and the recipient like each other
+	LikeEventTypeSuperLike                          // The actor super liked the recipient
+	LikeEventTypeUnmatch                            // The like that made a match between the users has been undone
+)
+
+// Event pushed to the recipient when a new like, super like or match is recorded, or when a match is undone
+type LikeEvent struct {
+	Type        LikeEventType
+	ActorID     entity.UserID // The other user of the like or match
+	RecipientID entity.UserID // The user receiving the event
+	OccurredAt  time.Time     // When the decision was recorded
+	Cursor      string        // Opaque position of the event in the stream of the recipient, set by the hub
+}
+
+// Publishes the like events, publishing must never block the caller
+type LikePublisher interface {
+	Publish(ctx context.Context, event LikeEvent)
+}
+
+// Stream of like events of a recipient. The events channel is closed when the subscription
+// ends, Err tells why it ended (i.e. the consumer was too slow or the hub has been closed).
+type LikeSubscription interface {
+	Events() <-chan LikeEvent
+	Err() error
+	Close()
+}
+
+// Subscribes to the like events of a recipient. When a cursor is given the events published
+// after it are replayed first, so a client can resume the stream after a reconnection.
+type LikeSubscriber interface {
+	Subscribe(ctx context.Context, recipientID entity.UserID, resumeCursor string) (LikeSubscription, error)
+}
+
+// Forgets the erased users: their subscriptions end and the retained events of them or about them are removed
+type LikeForgetter interface {
+	Forget(userID entity.UserID)
+}
+
+// In-process pub/sub hub of like events
+type LikeHub interface {
+	LikePublisher
+	LikeSubscriber
+	LikeForgetter
+}
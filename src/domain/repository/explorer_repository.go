@@ -2,16 +2,82 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/lokker96/grpc_project/domain/entity"
 )
 
 type ExplorerRepository interface {
+	LikesReadModel
+	OutboxRepository
 	CreateUser(ctx context.Context, user *entity.User) error
+	// Returns the user, a deleted user is not found like a user that never existed
+	GetUser(ctx context.Context, userID entity.UserID) (*entity.User, error)
+	// Deactivates the user and returns it, deactivating it again keeps the first deactivation date.
+	// The deactivation is appended to the decision log, not to the outbox.
+	DeactivateUser(ctx context.Context, userID entity.UserID) (*entity.User, error)
+	// Soft deletes the user, its decisions are kept but hidden like the ones of a deactivated user.
+	// The deletion is appended to the decision log, not to the outbox.
+	DeleteUser(ctx context.Context, userID entity.UserID) error
+	// Erases the user, deleted or not, and every decision, decision change, decision event, decision log event, outbox
+	// event, block and report it made or received in a single transaction, the erasure is recorded in the same transaction.
+	// Its DecisionsErased is set to the decisions deleted.
+	EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) error
+	// Inserts a new decision and appends it to the decision log and the outbox, with the match it makes if any.
+	// It isn't recorded in the decision history, it can't be undone.
 	CreateDecision(ctx context.Context, decision *entity.Decision) error
-	GetDecisionsForRecipientId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error)
-	GetDecisionsForUserId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error)
-	GetLikesCountByProfileId(ctx context.Context, profileID int) int64
-	UpdateDecision(ctx context.Context, userID int, recipientUserId int, liked bool) error
-	FindMutualLike(ctx context.Context, userID int, recipientUserID int) bool
+	// Returns up to limit decisions authored or received by the user, most recently updated first, starting
+	// after the cursor when one is given. The decisions with the inactive users are returned too.
+	ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *DecisionCursor, limit int) ([]entity.Decision, error)
+	// Creates or updates the decision of the author on the recipient in a single atomic statement and records
+	// the change in the decision history, the decision events, the decision log and the outbox. Returns the decision as it was
+	// before the call (nil when it has been created) and as it is now.
+	UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (*entity.Decision, *entity.Decision, error)
+	// Restores the decision changed by the most recent change of the author that hasn't been undone yet, deleting
+	// it when the change created it, records a decision event, a decision log event and an outbox event and returns the change.
+	// The change must have been made after since, otherwise nothing is undone. The bool is true when the users matched before the undo and don't anymore.
+	UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (*entity.DecisionChange, bool, error)
+	// Returns up to limit decision events matching the query, most recent first, starting after the cursor when one is given
+	ListDecisionEvents(ctx context.Context, query DecisionEventsQuery, cursor *DecisionEventCursor, limit int) ([]entity.DecisionEvent, error)
+	// Returns up to limit events of the decision log with an ID greater than afterID or among missingIDs, in the order
+	// of their IDs. An event may be committed after an event with a greater ID, the readers must not skip the missing
+	// IDs right away and pass the ones they gave up waiting for as missingIDs. Both are read in the same snapshot.
+	ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) ([]entity.DecisionLogEvent, error)
+	// Blocks the user and appends the block to the decision log, blocking the same user again is a no-op
+	BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error
+	// Lifts the block and appends it to the decision log, lifting a block that doesn't exist is a no-op
+	UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error
+	// True if any of the users has blocked the other
+	IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (bool, error)
+	CreateReport(ctx context.Context, report *entity.Report) error
+}
+
+// Read side of the decisions: the liker lists, the like counts and the matches. The repositories serve it from the
+// decisions table, the projections of the decision log serve it from memory.
+type LikesReadModel interface {
+	// Returns up to limit likes and super likes received by the recipient, filtered and ordered by the query,
+	// starting after the cursor when one is given. The likes of the deactivated and deleted users are left out
+	// of the lists, the counts and the matches, so are the decisions between blocked users.
+	ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query LikersQuery, cursor *DecisionCursor, limit int) ([]entity.Decision, error)
+	// Same as ListLikersForRecipientId but excludes the likers that the recipient has liked back
+	ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query LikersQuery, cursor *DecisionCursor, limit int) ([]entity.Decision, error)
+	// Counts the likes received by the profile by type, the types without any like are left out
+	GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error)
+	// True when the users like each other, both are active and none of them has blocked the other
+	FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error)
+	// Returns up to limit users who like the user and are liked back, starting after the cursor when one is given
+	ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *MatchCursor, limit int) ([]entity.Match, error)
+}
+
+// Transactional outbox: every decision log event of a decision or a match is copied into the outbox in the same
+// transaction, the relays publish the events and delete them. The delivery is at least once, the events of a pair are
+// delivered in order.
+type OutboxRepository interface {
+	// Claims up to limit events for claimDuration and returns them in the order of their IDs. Only the oldest event
+	// of each pair is claimable, and only once its next attempt is due and no other relay has a claim on it.
+	ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) ([]entity.OutboxEvent, error)
+	// Deletes the event once it has been published
+	DeleteOutboxEvent(ctx context.Context, eventID int64) error
+	// Records a failed attempt and releases the claim, the event and the next ones of its pair wait until nextAttemptAt
+	RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error
 }
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+// Keyset cursor used to page through decisions ordered by the most recent update first.
+// The ID breaks ties between decisions updated at the same time so the ordering is stable.
+type DecisionCursor struct {
+	Rank      int // Rank of the type of the last decision, only used by the liker lists in the ranked order
+	UpdatedAt time.Time
+	ID        int64
+}
+
+type LikersOrder int
+
+const (
+	LikersOrderRanked     LikersOrder = iota // Super likes first, then the most recent first
+	LikersOrderMostRecent                    // Most recent first, whatever the type
+)
+
+// Filter and order of the liker lists
+type LikersQuery struct {
+	Types []entity.DecisionType // Like types listed, every like type when empty
+	Order LikersOrder
+}
+
+// Keyset cursor used to page through matches ordered by the most recent match first.
+// The matched user ID breaks ties between matches made at the same time.
+type MatchCursor struct {
+	MatchedAt time.Time
+	UserID    entity.UserID
+}
+
+// Keyset cursor used to page through the decision events, most recent first.
+// The events are only appended so their IDs follow the order of the changes.
+type DecisionEventCursor struct {
+	ID int64
+}
+
+// Filter of the decision events
+type DecisionEventsQuery struct {
+	UserID      entity.UserID // Events of the decisions authored or received by the user
+	OtherUserID entity.UserID // Only the events between the two users when set, in both directions
+}
@@ -0,0 +1,1190 @@
+// Package repositorytest contains the conformance suite that every repository.ExplorerRepository
+// implementation must pass, so the in-memory and the postgres repositories behave the same way.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/audit"
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/magiconair/properties/assert"
+)
+
+// Returns a new and empty repository, it is called once per test
+type ExplorerRepositoryFactory func(t *testing.T) repository.ExplorerRepository
+
+// Runs the behavioural tests of the explorer repository against the implementation built by the factory
+func RunExplorerRepositoryTests(t *testing.T, newRepository ExplorerRepositoryFactory) {
+	tests := map[string]func(t *testing.T, explorerRepository repository.ExplorerRepository){
+		"ListLikersOnlyReturnsLikes":         testListLikersOnlyReturnsLikes,
+		"ListLikersIsPaged":                  testListLikersIsPaged,
+		"ListLikersRanksSuperLikesFirst":     testListLikersRanksSuperLikesFirst,
+		"ListNewLikersExcludesLikedBack":     testListNewLikersExcludesLikedBack,
+		"CountsLikesByType":                  testCountsLikesByType,
+		"FindsMutualLikes":                   testFindsMutualLikes,
+		"UpsertUpdatesTheSameDecision":       testUpsertUpdatesTheSameDecision,
+		"UnknownUsersAreNotFound":            testUnknownUsersAreNotFound,
+		"ListMatchesReturnsMutualLikes":      testListMatchesReturnsMutualLikes,
+		"EmptyResultsForUserWithoutDecision": testEmptyResultsForUserWithoutDecision,
+		"UserLifecycle":                      testUserLifecycle,
+		"InactiveUsersLikesAreHidden":        testInactiveUsersLikesAreHidden,
+		"ListDecisionsForUserIsPaged":        testListDecisionsForUserIsPaged,
+		"EraseUserRemovesItsDecisions":       testEraseUserRemovesItsDecisions,
+		"BlockedPairsAreExcluded":            testBlockedPairsAreExcluded,
+		"ReportsNeedExistingUsers":           testReportsNeedExistingUsers,
+		"UndoRestoresThePreviousDecision":    testUndoRestoresThePreviousDecision,
+		"UndoOnlyTheLastRecentDecision":      testUndoOnlyTheLastRecentDecision,
+		"DecisionEventsRecordEveryChange":    testDecisionEventsRecordEveryChange,
+		"DecisionLogRecordsEveryChange":      testDecisionLogRecordsEveryChange,
+		"DecisionLogRecordsUsersAndBlocks":   testDecisionLogRecordsUsersAndBlocks,
+		"OutboxIsClaimedInOrderByPair":       testOutboxIsClaimedInOrderByPair,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+// Creates count users and returns their ids in creation order
+func createUsers(t *testing.T, explorerRepository repository.ExplorerRepository, count int) []entity.UserID {
+	userIDs := make([]entity.UserID, 0, count)
+
+	for i := 0; i < count; i++ {
+		user := &entity.User{}
+		if err := explorerRepository.CreateUser(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs
+}
+
+func upsert(t *testing.T, explorerRepository repository.ExplorerRepository, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) {
+	if _, _, err := explorerRepository.UpsertDecision(context.Background(), authorID, recipientID, decisionType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func authorIDs(decisions []entity.Decision) []entity.UserID {
+	ids := make([]entity.UserID, 0, len(decisions))
+	for _, decision := range decisions {
+		ids = append(ids, decision.AuthorID)
+	}
+
+	return ids
+}
+
+func testListLikersOnlyReturnsLikes(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 4)
+
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypeLike)
+
+	likers, err := explorerRepository.ListLikersForRecipientId(context.Background(), users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Most recent like first
+	assert.Equal(t, authorIDs(likers), []entity.UserID{users[3], users[1]})
+
+	for _, liker := range likers {
+		assert.Equal(t, liker.RecipientID, users[0])
+		assert.Equal(t, liker.Type, entity.DecisionTypeLike)
+	}
+}
+
+func testListLikersIsPaged(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 5)
+
+	for _, authorID := range users[1:] {
+		upsert(t, explorerRepository, authorID, users[0], entity.DecisionTypeLike)
+	}
+
+	ctx := context.Background()
+
+	firstPage, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(firstPage), []entity.UserID{users[4], users[3], users[2]})
+
+	last := firstPage[len(firstPage)-1]
+
+	secondPage, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, &repository.DecisionCursor{
+		UpdatedAt: last.UpdatedAt,
+		ID:        last.ID,
+	}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(secondPage), []entity.UserID{users[1]})
+}
+
+func testListLikersRanksSuperLikesFirst(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 6)
+	ctx := context.Background()
+
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeSuperLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypeSuperLike)
+	upsert(t, explorerRepository, users[4], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[5], users[0], entity.DecisionTypePass)
+
+	// The super likes first, then the most recent first. The pages follow the same order.
+	ranked := make([]entity.Decision, 0)
+
+	var cursor *repository.DecisionCursor
+	for {
+		page, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, cursor, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ranked = append(ranked, page...)
+
+		if len(page) < 3 {
+			break
+		}
+
+		last := page[len(page)-1]
+		cursor = &repository.DecisionCursor{
+			Rank:      last.Type.Rank(),
+			UpdatedAt: last.UpdatedAt,
+			ID:        last.ID,
+		}
+	}
+
+	assert.Equal(t, authorIDs(ranked), []entity.UserID{users[3], users[1], users[4], users[2]})
+
+	mostRecent, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{
+		Order: repository.LikersOrderMostRecent,
+	}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(mostRecent), []entity.UserID{users[4], users[3], users[2], users[1]})
+
+	superLikes, err := explorerRepository.ListNewLikersForRecipientId(ctx, users[0], repository.LikersQuery{
+		Types: []entity.DecisionType{entity.DecisionTypeSuperLike},
+	}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(superLikes), []entity.UserID{users[3], users[1]})
+}
+
+func testListNewLikersExcludesLikedBack(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 4)
+
+	// users[1] and users[2] like users[0], users[0] likes users[1] back and passes on users[2]
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypePass)
+
+	newLikers, err := explorerRepository.ListNewLikersForRecipientId(context.Background(), users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(newLikers), []entity.UserID{users[2]})
+}
+
+func testCountsLikesByType(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 5)
+
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[4], users[0], entity.DecisionTypeSuperLike)
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	counts, err := explorerRepository.GetLikesCountsByProfileId(context.Background(), users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The passes are not counted at all
+	assert.Equal(t, counts, map[entity.DecisionType]int64{
+		entity.DecisionTypeLike:      2,
+		entity.DecisionTypeSuperLike: 1,
+	})
+}
+
+func testFindsMutualLikes(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	mutual, err := explorerRepository.FindMutualLike(ctx, users[0], users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutual, false)
+
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+
+	// The mutual like is found from both sides
+	for _, pair := range [][2]entity.UserID{{users[0], users[1]}, {users[1], users[0]}} {
+		mutual, err = explorerRepository.FindMutualLike(ctx, pair[0], pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, mutual, true)
+	}
+
+	// A pass in return is not a mutual like
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypePass)
+
+	mutual, err = explorerRepository.FindMutualLike(ctx, users[0], users[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutual, false)
+}
+
+func testUpsertUpdatesTheSameDecision(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 2)
+	ctx := context.Background()
+
+	previous, created, err := explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypeLike)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, previous, (*entity.Decision)(nil))
+	assert.Equal(t, created.AuthorID, users[0])
+	assert.Equal(t, created.RecipientID, users[1])
+	assert.Equal(t, created.Type, entity.DecisionTypeLike)
+
+	previous, updated, err := explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypePass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, previous.ID, created.ID)
+	assert.Equal(t, previous.Type, entity.DecisionTypeLike)
+	assert.Equal(t, updated.ID, created.ID)
+	assert.Equal(t, updated.Type, entity.DecisionTypePass)
+
+	if updated.UpdatedAt.Before(created.UpdatedAt) {
+		t.Fatal("expected the update time to move forward")
+	}
+
+	// The update is visible to the readers and no other decision has been created
+	counts, err := explorerRepository.GetLikesCountsByProfileId(ctx, users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(0))
+
+	_, _, err = explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypeSuperLike)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[1], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(likers), 1)
+	assert.Equal(t, likers[0].ID, created.ID)
+	assert.Equal(t, likers[0].Type, entity.DecisionTypeSuperLike)
+}
+
+func testUnknownUsersAreNotFound(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 1)
+	ctx := context.Background()
+
+	unknownUserID := users[0] + 1000
+
+	_, _, err := explorerRepository.UpsertDecision(ctx, users[0], unknownUserID, entity.DecisionTypeLike)
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	_, _, err = explorerRepository.UpsertDecision(ctx, unknownUserID, users[0], entity.DecisionTypeLike)
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	err = explorerRepository.CreateDecision(ctx, &entity.Decision{
+		AuthorID:    unknownUserID,
+		RecipientID: users[0],
+		Type:        entity.DecisionTypeLike,
+	})
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+}
+
+func testListMatchesReturnsMutualLikes(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 4)
+	ctx := context.Background()
+
+	// users[0] matches with users[1] and then with users[2], users[3] likes users[0] who passes
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[3], entity.DecisionTypePass)
+
+	matches, err := explorerRepository.ListMatchesForUserId(ctx, users[0], nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Most recent match first
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].UserID, users[2])
+
+	matches, err = explorerRepository.ListMatchesForUserId(ctx, users[0], &repository.MatchCursor{
+		MatchedAt: matches[0].MatchedAt,
+		UserID:    matches[0].UserID,
+	}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].UserID, users[1])
+
+	// The match is visible from the other side too
+	matches, err = explorerRepository.ListMatchesForUserId(ctx, users[1], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].UserID, users[0])
+}
+
+func testEmptyResultsForUserWithoutDecision(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 1)
+	ctx := context.Background()
+
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(likers), 0)
+
+	newLikers, err := explorerRepository.ListNewLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(newLikers), 0)
+
+	counts, err := explorerRepository.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(0))
+
+	matches, err := explorerRepository.ListMatchesForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 0)
+}
+
+func testUserLifecycle(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 1)
+	ctx := context.Background()
+
+	user, err := explorerRepository.GetUser(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, user.ID, users[0])
+	assert.Equal(t, user.IsDeactivated(), false)
+
+	deactivated, err := explorerRepository.DeactivateUser(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, deactivated.IsDeactivated(), true)
+
+	// Deactivating again keeps the first date
+	again, err := explorerRepository.DeactivateUser(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, again.DeactivatedAt.Equal(*deactivated.DeactivatedAt), true)
+
+	user, err = explorerRepository.GetUser(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, user.IsDeactivated(), true)
+
+	// A deleted user is not found anymore, by any method
+	if err := explorerRepository.DeleteUser(ctx, users[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = explorerRepository.GetUser(ctx, users[0])
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	_, err = explorerRepository.DeactivateUser(ctx, users[0])
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	err = explorerRepository.DeleteUser(ctx, users[0])
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	_, err = explorerRepository.GetUser(ctx, users[0]+1000)
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+}
+
+func testInactiveUsersLikesAreHidden(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 4)
+	ctx := context.Background()
+
+	// users[1] and users[2] match with users[0], users[3] likes users[0]
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypeLike)
+
+	if _, err := explorerRepository.DeactivateUser(ctx, users[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := explorerRepository.DeleteUser(ctx, users[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(likers), []entity.UserID{users[2]})
+
+	newLikers, err := explorerRepository.ListNewLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(newLikers), 0)
+
+	counts, err := explorerRepository.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(1))
+
+	matches, err := explorerRepository.ListMatchesForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].UserID, users[2])
+
+	// The mutual likes follow the matches, in both directions
+	for _, pair := range [][2]entity.UserID{{users[0], users[1]}, {users[1], users[0]}} {
+		mutualLike, err := explorerRepository.FindMutualLike(ctx, pair[0], pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, mutualLike, false)
+	}
+
+	mutualLike, err := explorerRepository.FindMutualLike(ctx, users[0], users[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutualLike, true)
+}
+
+func testListDecisionsForUserIsPaged(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 4)
+	ctx := context.Background()
+
+	// Authored and received decisions are listed, liked or not, the decisions between the others are not
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[3], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[2], entity.DecisionTypeLike)
+
+	firstPage, err := explorerRepository.ListDecisionsForUserId(ctx, users[0], nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(firstPage), []entity.UserID{users[3], users[2]})
+
+	last := firstPage[len(firstPage)-1]
+
+	secondPage, err := explorerRepository.ListDecisionsForUserId(ctx, users[0], &repository.DecisionCursor{
+		UpdatedAt: last.UpdatedAt,
+		ID:        last.ID,
+	}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(secondPage), []entity.UserID{users[0]})
+	assert.Equal(t, secondPage[0].RecipientID, users[1])
+}
+
+func testEraseUserRemovesItsDecisions(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[1], users[2], entity.DecisionTypeLike)
+
+	// Deleted users can still be erased
+	if err := explorerRepository.DeleteUser(ctx, users[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	erasure := &entity.UserErasure{RequestedBy: "user"}
+	if err := explorerRepository.EraseUser(ctx, users[0], erasure); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, erasure.DecisionsErased, int64(3))
+
+	decisions, err := explorerRepository.ListDecisionsForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(decisions), 0)
+
+	// The decisions between the other users are kept
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[2], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(likers), []entity.UserID{users[1]})
+
+	counts, err := explorerRepository.GetLikesCountsByProfileId(ctx, users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(0))
+
+	// An erased user is gone for good
+	err = explorerRepository.EraseUser(ctx, users[0], &entity.UserErasure{RequestedBy: "user"})
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+}
+
+func testBlockedPairsAreExcluded(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+
+	// users[0] matches with users[1] and is liked by users[2]
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+
+	// users[1] blocks users[0], the exclusion applies in both directions. Blocking twice is a no-op.
+	for i := 0; i < 2; i++ {
+		if err := explorerRepository.BlockUser(ctx, users[1], users[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blocked, err := explorerRepository.IsBlocked(ctx, users[0], users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, blocked, true)
+
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(likers), []entity.UserID{users[2]})
+
+	newLikers, err := explorerRepository.ListNewLikersForRecipientId(ctx, users[0], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(newLikers), []entity.UserID{users[2]})
+
+	// The blocker doesn't see the likes of the blocked user either
+	counts, err := explorerRepository.GetLikesCountsByProfileId(ctx, users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(0))
+
+	mutualLike, err := explorerRepository.FindMutualLike(ctx, users[0], users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutualLike, false)
+
+	matches, err := explorerRepository.ListMatchesForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 0)
+
+	// The blocked user can't lift the block, the blocker can
+	if err := explorerRepository.UnblockUser(ctx, users[0], users[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	mutualLike, err = explorerRepository.FindMutualLike(ctx, users[1], users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutualLike, false)
+
+	if err := explorerRepository.UnblockUser(ctx, users[1], users[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	mutualLike, err = explorerRepository.FindMutualLike(ctx, users[0], users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, mutualLike, true)
+
+	counts, err = explorerRepository.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(2))
+
+	err = explorerRepository.BlockUser(ctx, users[0], users[2]+1000)
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+}
+
+func testReportsNeedExistingUsers(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 2)
+	ctx := context.Background()
+
+	report := &entity.Report{
+		ReporterID: users[0],
+		ReportedID: users[1],
+		Reason:     entity.ReportReasonSpam,
+	}
+
+	if err := explorerRepository.CreateReport(ctx, report); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.ID == 0 {
+		t.Fatal("expected the report to get an id")
+	}
+
+	err := explorerRepository.CreateReport(ctx, &entity.Report{
+		ReporterID: users[0],
+		ReportedID: users[1] + 1000,
+		Reason:     entity.ReportReasonSpam,
+	})
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", err)
+	}
+
+	// The reports and the blocks of an erased user go with it
+	if err := explorerRepository.BlockUser(ctx, users[1], users[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := explorerRepository.EraseUser(ctx, users[1], &entity.UserErasure{RequestedBy: "user"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testUndoRestoresThePreviousDecision(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 2)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+
+	_, pass, err := explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypePass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The like makes a match
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	change, matchDissolved, err := explorerRepository.UndoLastDecision(ctx, users[0], since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, change.RecipientID, users[1])
+	assert.Equal(t, change.Type, entity.DecisionTypeLike)
+	assert.Equal(t, *change.PreviousType, entity.DecisionTypePass)
+	assert.Equal(t, change.UndoneAt != nil, true)
+	assert.Equal(t, matchDissolved, true)
+
+	// The pass is back exactly as it was
+	decisions, err := explorerRepository.ListDecisionsForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(decisions), 2)
+	assert.Equal(t, decisions[0].AuthorID, users[0])
+	assert.Equal(t, decisions[0].Type, entity.DecisionTypePass)
+	assert.Equal(t, decisions[0].UpdatedAt.Equal(pass.UpdatedAt), true)
+
+	matches, err := explorerRepository.ListMatchesForUserId(ctx, users[1], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 0)
+
+	// Undoing again removes the pass, it created the decision
+	change, matchDissolved, err = explorerRepository.UndoLastDecision(ctx, users[0], since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, change.Type, entity.DecisionTypePass)
+	assert.Equal(t, change.PreviousType == nil, true)
+	assert.Equal(t, matchDissolved, false)
+
+	decisions, err = explorerRepository.ListDecisionsForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(decisions), 1)
+	assert.Equal(t, decisions[0].AuthorID, users[1])
+
+	// Then there is nothing left to undo
+	_, _, err = explorerRepository.UndoLastDecision(ctx, users[0], since)
+	if !errors.As(err, &domainError.DecisionNotFoundErr{}) {
+		t.Fatalf("expected a decision not found error, got %v", err)
+	}
+}
+
+func testUndoOnlyTheLastRecentDecision(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	// The decision is older than the window, it stays
+	_, _, err := explorerRepository.UndoLastDecision(ctx, users[0], time.Now().Add(time.Hour))
+	if !errors.As(err, &domainError.UndoWindowExpiredErr{}) {
+		t.Fatalf("expected an undo window expired error, got %v", err)
+	}
+
+	likers, err := explorerRepository.ListLikersForRecipientId(ctx, users[1], repository.LikersQuery{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, authorIDs(likers), []entity.UserID{users[0]})
+
+	// Only the decisions of the author can be undone
+	_, _, err = explorerRepository.UndoLastDecision(ctx, users[1], time.Now().Add(-time.Hour))
+	if !errors.As(err, &domainError.DecisionNotFoundErr{}) {
+		t.Fatalf("expected a decision not found error, got %v", err)
+	}
+
+	// The changes on an erased user are erased with it
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+
+	if err := explorerRepository.EraseUser(ctx, users[2], &entity.UserErasure{RequestedBy: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	change, _, err := explorerRepository.UndoLastDecision(ctx, users[0], time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, change.RecipientID, users[1])
+}
+
+func testDecisionEventsRecordEveryChange(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := audit.NewContext(context.Background(), "request-1")
+	pass := entity.DecisionTypePass
+	like := entity.DecisionTypeLike
+
+	if _, _, err := explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypePass); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := explorerRepository.UpsertDecision(ctx, users[0], users[1], entity.DecisionTypeLike); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := explorerRepository.UndoLastDecision(ctx, users[0], time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Made outside of a call, without a request ID
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+
+	events, err := explorerRepository.ListDecisionEvents(ctx, repository.DecisionEventsQuery{UserID: users[0], OtherUserID: users[1]}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Most recent first, the undo restores the pass
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	assert.Equal(t, events[0].Cause, entity.DecisionEventCauseUndo)
+	assert.Equal(t, events[0].OldType, &like)
+	assert.Equal(t, events[0].NewType, &pass)
+	assert.Equal(t, events[1].Cause, entity.DecisionEventCausePut)
+	assert.Equal(t, events[1].OldType, &pass)
+	assert.Equal(t, events[1].NewType, &like)
+	assert.Equal(t, events[2].OldType, (*entity.DecisionType)(nil))
+	assert.Equal(t, events[2].NewType, &pass)
+
+	for _, event := range events {
+		assert.Equal(t, event.AuthorID, users[0])
+		assert.Equal(t, event.RecipientID, users[1])
+		assert.Equal(t, event.RequestID, "request-1")
+	}
+
+	// The events received by the user are listed too, and the list is paged by id
+	events, err = explorerRepository.ListDecisionEvents(ctx, repository.DecisionEventsQuery{UserID: users[0]}, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].AuthorID, users[2])
+	assert.Equal(t, events[0].RequestID, "")
+
+	events, err = explorerRepository.ListDecisionEvents(ctx, repository.DecisionEventsQuery{UserID: users[0]}, &repository.DecisionEventCursor{ID: events[0].ID}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(events), 3)
+
+	// The events are erased with the user
+	if err := explorerRepository.EraseUser(ctx, users[1], &entity.UserErasure{RequestedBy: "user"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err = explorerRepository.ListDecisionEvents(ctx, repository.DecisionEventsQuery{UserID: users[0]}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].AuthorID, users[2])
+}
+
+func testDecisionLogRecordsEveryChange(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+	pass := entity.DecisionTypePass
+	like := entity.DecisionTypeLike
+
+	created := &entity.Decision{AuthorID: users[2], RecipientID: users[0], Type: entity.DecisionTypeLike}
+	if err := explorerRepository.CreateDecision(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+
+	// The like back is removed, then the pass is restored
+	if _, _, err := explorerRepository.UndoLastDecision(ctx, users[1], since); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := explorerRepository.UndoLastDecision(ctx, users[0], since); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := explorerRepository.ReadDecisionLog(ctx, 0, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 7 {
+		t.Fatalf("expected 7 events, got %d", len(events))
+	}
+
+	types := make([]entity.DecisionLogEventType, 0, len(events))
+	for _, logEvent := range events {
+		types = append(types, logEvent.Type)
+	}
+
+	assert.Equal(t, types, []entity.DecisionLogEventType{
+		entity.DecisionLogEventDecisionMade,
+		entity.DecisionLogEventDecisionMade,
+		entity.DecisionLogEventDecisionChanged,
+		entity.DecisionLogEventDecisionMade,
+		entity.DecisionLogEventMatchCreated,
+		entity.DecisionLogEventDecisionChanged,
+		entity.DecisionLogEventDecisionChanged,
+	})
+
+	// The events hold the decision after them
+	assert.Equal(t, events[0].Decision().ID, created.ID)
+	assert.Equal(t, events[0].Decision().AuthorID, users[2])
+	assert.Equal(t, events[2].NewType, &like)
+	assert.Equal(t, events[2].DecisionID, events[1].DecisionID)
+
+	// The match is made by the like back, the removal and the match don't hold a decision
+	assert.Equal(t, events[4].AuthorID, users[1])
+	assert.Equal(t, events[4].RecipientID, users[0])
+	assert.Equal(t, events[4].Decision(), (*entity.Decision)(nil))
+	assert.Equal(t, events[5].AuthorID, users[1])
+	assert.Equal(t, events[5].Decision(), (*entity.Decision)(nil))
+	assert.Equal(t, events[6].NewType, &pass)
+
+	for i := 1; i < len(events); i++ {
+		if events[i].ID <= events[i-1].ID {
+			t.Fatalf("expected the events in the order of their IDs, got %d after %d", events[i].ID, events[i-1].ID)
+		}
+	}
+
+	// The log is read after an ID
+	page, err := explorerRepository.ReadDecisionLog(ctx, events[4].ID, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(page), 1)
+	assert.Equal(t, page[0].ID, events[5].ID)
+
+	// The missing IDs below afterID are read first, with the same limit
+	page, err = explorerRepository.ReadDecisionLog(ctx, events[4].ID, []int64{events[5].ID, events[1].ID}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(page), 2)
+	assert.Equal(t, page[0].ID, events[1].ID)
+	assert.Equal(t, page[1].ID, events[5].ID)
+
+	// The events are erased with the user
+	if err := explorerRepository.EraseUser(ctx, users[2], &entity.UserErasure{RequestedBy: "user"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err = explorerRepository.ReadDecisionLog(ctx, 0, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(events), 6)
+	assert.Equal(t, events[0].AuthorID, users[0])
+}
+
+func testDecisionLogRecordsUsersAndBlocks(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := context.Background()
+
+	// Only the changes are logged, deactivating, blocking or unblocking again isn't
+	for i := 0; i < 2; i++ {
+		if _, err := explorerRepository.DeactivateUser(ctx, users[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := explorerRepository.BlockUser(ctx, users[1], users[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := explorerRepository.UnblockUser(ctx, users[1], users[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := explorerRepository.DeleteUser(ctx, users[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := explorerRepository.ReadDecisionLog(ctx, 0, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := make([]entity.DecisionLogEventType, 0, len(events))
+	for _, logEvent := range events {
+		types = append(types, logEvent.Type)
+	}
+
+	assert.Equal(t, types, []entity.DecisionLogEventType{
+		entity.DecisionLogEventUserDeactivated,
+		entity.DecisionLogEventUserBlocked,
+		entity.DecisionLogEventUserUnblocked,
+		entity.DecisionLogEventUserDeleted,
+	})
+
+	// The user events have the user as author and recipient, the block events the blocker as author
+	assert.Equal(t, events[0].AuthorID, users[0])
+	assert.Equal(t, events[0].RecipientID, users[0])
+	assert.Equal(t, events[1].AuthorID, users[1])
+	assert.Equal(t, events[1].RecipientID, users[2])
+	assert.Equal(t, events[3].Decision(), (*entity.Decision)(nil))
+
+	// They only hide likes and aren't published
+	claimOutbox(t, explorerRepository)
+}
+
+// Claims the due outbox events and fails if their IDs aren't the expected ones
+func claimOutbox(t *testing.T, explorerRepository repository.ExplorerRepository, want ...int64) []entity.OutboxEvent {
+	outboxEvents, err := explorerRepository.ClaimOutboxEvents(context.Background(), 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]int64, 0, len(outboxEvents))
+	for _, outboxEvent := range outboxEvents {
+		ids = append(ids, outboxEvent.ID)
+	}
+
+	if !slices.Equal(ids, want) {
+		t.Fatalf("expected the outbox events %v to be claimed, got %v", want, ids)
+	}
+
+	return outboxEvents
+}
+
+func testOutboxIsClaimedInOrderByPair(t *testing.T, explorerRepository repository.ExplorerRepository) {
+	users := createUsers(t, explorerRepository, 3)
+	ctx := audit.NewContext(context.Background(), "request-1")
+	like := entity.DecisionTypeLike
+
+	// Three events for the pair of users[0] and users[1], the like back making a match, one for the other pair
+	for _, decision := range []entity.Decision{
+		{AuthorID: users[0], RecipientID: users[1], Type: entity.DecisionTypeLike},
+		{AuthorID: users[1], RecipientID: users[0], Type: entity.DecisionTypeLike},
+		{AuthorID: users[2], RecipientID: users[0], Type: entity.DecisionTypePass},
+	} {
+		if _, _, err := explorerRepository.UpsertDecision(ctx, decision.AuthorID, decision.RecipientID, decision.Type); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := explorerRepository.ReadDecisionLog(ctx, 0, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+
+	// Only the oldest event of every pair can be claimed
+	claimed := claimOutbox(t, explorerRepository, events[0].ID, events[3].ID)
+
+	assert.Equal(t, claimed[0].Type, entity.DecisionLogEventDecisionMade)
+	assert.Equal(t, claimed[0].PairKey, entity.PairKey(users[1], users[0]))
+	assert.Equal(t, claimed[0].AuthorID, users[0])
+	assert.Equal(t, claimed[0].RecipientID, users[1])
+	assert.Equal(t, claimed[0].DecisionType, &like)
+	assert.Equal(t, claimed[0].RequestID, "request-1")
+	assert.Equal(t, claimed[0].Attempts, 0)
+
+	// The claimed events are reserved, the next events of their pairs wait for them
+	claimOutbox(t, explorerRepository)
+
+	if err := explorerRepository.DeleteOutboxEvent(ctx, events[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := explorerRepository.RetryOutboxEvent(ctx, events[3].ID, time.Now().Add(time.Hour), "unavailable"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The retried event isn't due yet, the published one lets the next event of its pair through
+	claimOutbox(t, explorerRepository, events[1].ID)
+
+	if err := explorerRepository.RetryOutboxEvent(ctx, events[1].ID, time.Now().Add(-time.Second), "unavailable"); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed = claimOutbox(t, explorerRepository, events[1].ID)
+
+	assert.Equal(t, claimed[0].Attempts, 1)
+	assert.Equal(t, claimed[0].LastError, "unavailable")
+
+	// The events are erased with the user
+	if err := explorerRepository.EraseUser(ctx, users[1], &entity.UserErasure{RequestedBy: "user"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := explorerRepository.RetryOutboxEvent(ctx, events[3].ID, time.Now().Add(-time.Second), "unavailable"); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed = claimOutbox(t, explorerRepository, events[3].ID)
+
+	assert.Equal(t, claimed[0].Type, entity.DecisionLogEventDecisionMade)
+	assert.Equal(t, claimed[0].Attempts, 2)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+)
+
+// Decision of a PutDecision request, the decision_type when it is set and the deprecated liked_recipient otherwise
+func decisionTypeOf(request *epv2.PutDecisionRequest) (entity.DecisionType, error) {
+	switch request.GetDecisionType() {
+	case epv2.DecisionType_DECISION_TYPE_UNSPECIFIED:
+		if request.GetLikedRecipient() {
+			return entity.DecisionTypeLike, nil
+		}
+
+		return entity.DecisionTypePass, nil
+	case epv2.DecisionType_DECISION_TYPE_PASS:
+		return entity.DecisionTypePass, nil
+	case epv2.DecisionType_DECISION_TYPE_LIKE:
+		return entity.DecisionTypeLike, nil
+	case epv2.DecisionType_DECISION_TYPE_SUPER_LIKE:
+		return entity.DecisionTypeSuperLike, nil
+	default:
+		return "", domainError.NewInvalidArgumentErr("decision_type", "must be a known decision type")
+	}
+}
+
+// Filter and order of a liker list request, only the like types can be listed
+func likersQueryOf(request *epv2.ListLikedYouRequest) (repository.LikersQuery, error) {
+	query := repository.LikersQuery{
+		Order: repository.LikersOrderRanked,
+	}
+
+	switch request.GetOrder() {
+	case epv2.ListLikedYouRequest_ORDER_UNSPECIFIED, epv2.ListLikedYouRequest_ORDER_SUPER_LIKES_FIRST:
+	case epv2.ListLikedYouRequest_ORDER_MOST_RECENT:
+		query.Order = repository.LikersOrderMostRecent
+	default:
+		return query, domainError.NewInvalidArgumentErr("order", "must be a known order")
+	}
+
+	for _, decisionType := range request.GetDecisionTypes() {
+		switch decisionType {
+		case epv2.DecisionType_DECISION_TYPE_LIKE:
+			query.Types = append(query.Types, entity.DecisionTypeLike)
+		case epv2.DecisionType_DECISION_TYPE_SUPER_LIKE:
+			query.Types = append(query.Types, entity.DecisionTypeSuperLike)
+		default:
+			return query, domainError.NewInvalidArgumentErr("decision_types", "can only hold DECISION_TYPE_LIKE and DECISION_TYPE_SUPER_LIKE")
+		}
+	}
+
+	return query, nil
+}
+
+func toDecisionType(decisionType entity.DecisionType) epv2.DecisionType {
+	switch decisionType {
+	case entity.DecisionTypePass:
+		return epv2.DecisionType_DECISION_TYPE_PASS
+	case entity.DecisionTypeLike:
+		return epv2.DecisionType_DECISION_TYPE_LIKE
+	case entity.DecisionTypeSuperLike:
+		return epv2.DecisionType_DECISION_TYPE_SUPER_LIKE
+	default:
+		return epv2.DecisionType_DECISION_TYPE_UNSPECIFIED
+	}
+}
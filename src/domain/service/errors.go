@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validates a user id sent as a number by the v2 API, the field is the proto field name reported to the client
+func validateUserID(field string, value int64) (entity.UserID, error) {
+	userID, err := entity.NewUserID(value)
+	if err != nil {
+		return 0, domainError.NewInvalidArgumentErr(field, err.Error())
+	}
+
+	return userID, nil
+}
+
+// Parses a user id sent as a string by the v1 API
+func parseUserID(field string, value string) (entity.UserID, error) {
+	userID, err := entity.ParseUserID(value)
+	if err != nil {
+		return 0, domainError.NewInvalidArgumentErr(field, err.Error())
+	}
+
+	return userID, nil
+}
+
+// Checks that the caller of the request can act as the user, the users can only access their own data
+// while the services and the admins can access the data of everyone
+func authorize(ctx context.Context, userID entity.UserID) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		// The authentication interceptor always sets the principal, fail closed if it is missing
+		return domainError.NewUnauthenticatedErr("no authenticated caller")
+	}
+
+	if !principal.CanActAs(userID) {
+		return domainError.NewPermissionDeniedErr()
+	}
+
+	return nil
+}
+
+// Checks that the caller is a service or an admin, the users can't call the back office methods
+func authorizeService(ctx context.Context) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return domainError.NewUnauthenticatedErr("no authenticated caller")
+	}
+
+	if !principal.HasRole(auth.RoleService) && !principal.HasRole(auth.RoleAdmin) {
+		return domainError.NewPermissionDeniedErr()
+	}
+
+	return nil
+}
+
+// Checks that the caller is an admin, the services can't call the support methods either
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return domainError.NewUnauthenticatedErr("no authenticated caller")
+	}
+
+	if !principal.HasRole(auth.RoleAdmin) {
+		return domainError.NewPermissionDeniedErr()
+	}
+
+	return nil
+}
+
+// Translates the errors returned by the handlers into gRPC status errors so the clients
+// get a meaningful code instead of codes.Unknown. Every handler must go through it.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Already translated, i.e. returned by another gRPC call
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var invalidArgumentErr domainError.InvalidArgumentErr
+	if errors.As(err, &invalidArgumentErr) {
+		return invalidArgumentStatus(err.Error(), invalidArgumentErr).Err()
+	}
+
+	var databaseErr domainError.DatabaseErr
+
+	switch {
+	case errors.As(err, &domainError.DecisionNotFoundErr{}),
+		errors.As(err, &domainError.UserNotFoundErr{}):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &domainError.UserDeactivatedErr{}),
+		errors.As(err, &domainError.UndoWindowExpiredErr{}):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &domainError.UnauthenticatedErr{}):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.As(err, &domainError.PermissionDeniedErr{}):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &domainError.SlowConsumerErr{}):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.As(err, &domainError.ResumeCursorExpiredErr{}):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.As(err, &domainError.UnavailableErr{}):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.As(err, &databaseErr) && databaseErr.Unavailable():
+		slog.Error("the database is unavailable", slog.String("error", err.Error()))
+		return status.Error(codes.Unavailable, "the database is unavailable, retry later")
+	default:
+		// The failures of the server aren't detailed to the clients, they are logged instead
+		slog.Error("internal error", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// Builds an InvalidArgument status carrying the field violation as a BadRequest detail
+func invalidArgumentStatus(message string, invalidArgumentErr domainError.InvalidArgumentErr) *status.Status {
+	st := status.New(codes.InvalidArgument, message)
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       invalidArgumentErr.Field,
+				Description: invalidArgumentErr.Description,
+			},
+		},
+	})
+	if err != nil {
+		// The details can't be attached, the message alone still describes the violation
+		return st
+	}
+
+	return detailed
+}
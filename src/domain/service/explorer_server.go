@@ -4,180 +4,666 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
+	"time"
 
 	"github.com/lokker96/grpc_project/domain/entity"
 	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/event"
 	"github.com/lokker96/grpc_project/domain/repository"
-	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
 )
 
-// Embeds the gRPC server that provides the endpoints and implements them
+// Embeds the gRPC server that provides the endpoints and implements them.
+// It serves the v2 API, the v1 API is served by ExploreServerV1 on top of it.
 type ExploreServer struct {
-	ep.UnimplementedExploreServiceServer
+	epv2.UnimplementedExploreServiceServer
 	explorerRepository repository.ExplorerRepository // Explorer repository which implements a postgres DB and method to access the data
+	likesReadModel     repository.LikesReadModel     // Serves the liker lists, the like counts and the matches, the repository by default
+	likeHub            event.LikeHub                 // Pub/sub hub used to push the new likes and matches to the watchers
+	decisionRecorder   event.DecisionRecorder        // Counts the likes, passes and matches created
+	undoWindow         time.Duration                 // How long after a decision the actor can still undo it
 }
 
-func NewExplorerServer(explorerRepository repository.ExplorerRepository) *ExploreServer {
-	return &ExploreServer{explorerRepository: explorerRepository}
+// Default time allowed to undo a decision
+const DefaultUndoWindow = 5 * time.Minute
+
+func NewExplorerServer(explorerRepository repository.ExplorerRepository, likeHub event.LikeHub, decisionRecorder event.DecisionRecorder) *ExploreServer {
+	return &ExploreServer{
+		explorerRepository: explorerRepository,
+		likesReadModel:     explorerRepository,
+		likeHub:            likeHub,
+		decisionRecorder:   decisionRecorder,
+		undoWindow:         DefaultUndoWindow,
+	}
+}
+
+// Sets how long after a decision the actor can still undo it, 0 disables the undo
+func (s *ExploreServer) SetUndoWindow(undoWindow time.Duration) {
+	s.undoWindow = undoWindow
+}
+
+// Sets the read model serving the liker lists, the like counts and the matches, i.e. the projections of the decision log
+func (s *ExploreServer) SetLikesReadModel(likesReadModel repository.LikesReadModel) {
+	s.likesReadModel = likesReadModel
 }
 
 // Helper function for making testing easier
 // Dataset:
-// User IDs: [1, 2, 3, 4]
+// User IDs: [1, 2, 3, 4] on an empty store
 // Like: 1 -> 2
 // Like: 2 -> 1
 // Like: 4 -> 1
-func (s *ExploreServer) BuildDummyDataset() {
-	ctx := context.Background()
-
-	s.explorerRepository.CreateUser(ctx, &entity.User{})
-	s.explorerRepository.CreateUser(ctx, &entity.User{})
-	s.explorerRepository.CreateUser(ctx, &entity.User{})
-	s.explorerRepository.CreateUser(ctx, &entity.User{})
-
-	s.explorerRepository.CreateDecision(ctx, &entity.Decision{
-		AuthorID:    1,
-		RecipientID: 2,
-		Liked:       true,
-	})
+// The dataset is only inserted while user 1 doesn't exist, so a persistent database is seeded once
+func (s *ExploreServer) BuildDummyDataset(ctx context.Context) error {
+	_, err := s.explorerRepository.GetUser(ctx, 1)
+	if err == nil {
+		return nil
+	}
 
-	s.explorerRepository.CreateDecision(ctx, &entity.Decision{
-		AuthorID:    2,
-		RecipientID: 1,
-		Liked:       true,
-	})
+	if !errors.As(err, &domainError.UserNotFoundErr{}) {
+		return fmt.Errorf("error checking the dummy dataset: %w", err)
+	}
 
-	s.explorerRepository.CreateDecision(ctx, &entity.Decision{
-		AuthorID:    4,
-		RecipientID: 1,
-		Liked:       true,
-	})
+	userIDs := make([]entity.UserID, 0, 4)
+	for i := 0; i < 4; i++ {
+		user := &entity.User{}
+		if err := s.explorerRepository.CreateUser(ctx, user); err != nil {
+			return fmt.Errorf("error creating dummy user: %w", err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	// The decisions use the IDs given to the users, they are 1 to 4 unless the store had users before
+	decisions := []entity.Decision{
+		{AuthorID: userIDs[0], RecipientID: userIDs[1], Type: entity.DecisionTypeLike},
+		{AuthorID: userIDs[1], RecipientID: userIDs[0], Type: entity.DecisionTypeLike},
+		{AuthorID: userIDs[3], RecipientID: userIDs[0], Type: entity.DecisionTypeLike},
+	}
+
+	for _, decision := range decisions {
+		if err := s.explorerRepository.CreateDecision(ctx, &decision); err != nil {
+			return fmt.Errorf("error creating dummy decision: %w", err)
+		}
+	}
+
+	return nil
 }
 
-func (s *ExploreServer) ListLikedYou(ctx context.Context, request *ep.ListLikedYouRequest) (*ep.ListLikedYouResponse, error) {
-	likers := make([]*ep.ListLikedYouResponse_Liker, 0)
+func (s *ExploreServer) ListLikedYou(ctx context.Context, request *epv2.ListLikedYouRequest) (*epv2.ListLikedYouResponse, error) {
+	recipientUserID, err := validateUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "recipient_user_id", recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
 
-	recipientUserID, err := strconv.Atoi(request.GetRecipientUserId())
+	query, err := likersQueryOf(request)
 	if err != nil {
-		return nil, fmt.Errorf("error converting recipient user id string: %w", err)
+		return nil, toStatusError(err)
 	}
 
-	// Ideally we should check that the recipient user id exists first by calling a method to check
+	cursor, err := decodeDecisionCursor(request.PaginationToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 
-	liked := true
+	// One more decision than the page size is requested to know if there is a next page
+	decisions, err := s.likesReadModel.ListLikersForRecipientId(ctx, recipientUserID, query, cursor, LikersPageSize+1)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error getting liked decisions for recipient id: %w", err))
+	}
+
+	decisions, nextPaginationToken := pageOf(decisions, LikersPageSize, decisionPosition)
+
+	return &epv2.ListLikedYouResponse{
+		Likers:              toLikers(decisions),
+		NextPaginationToken: nextPaginationToken,
+	}, nil
+}
+
+func (s *ExploreServer) ListNewLikedYou(ctx context.Context, request *epv2.ListLikedYouRequest) (*epv2.ListLikedYouResponse, error) {
+	recipientUserID, err := validateUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "recipient_user_id", recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	query, err := likersQueryOf(request)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	cursor, err := decodeDecisionCursor(request.PaginationToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 
-	decisions, err := s.explorerRepository.GetDecisionsForRecipientId(ctx, recipientUserID, &liked)
+	// The repository filters out the likers that have been liked back by the recipient
+	decisions, err := s.likesReadModel.ListNewLikersForRecipientId(ctx, recipientUserID, query, cursor, LikersPageSize+1)
 	if err != nil {
-		return nil, fmt.Errorf("error getting liked decisions for recipient id: %w", err)
-	} else if errors.Is(err, &domainError.DecisionNotFoundErr{}) {
-		// Do nothing
+		return nil, toStatusError(fmt.Errorf("error getting new liked decisions for recipient id: %w", err))
 	}
 
+	decisions, nextPaginationToken := pageOf(decisions, LikersPageSize, decisionPosition)
+
+	return &epv2.ListLikedYouResponse{
+		Likers:              toLikers(decisions),
+		NextPaginationToken: nextPaginationToken,
+	}, nil
+}
+
+// Maps the liked decisions to the likers returned by the list endpoints
+func toLikers(decisions []entity.Decision) []*epv2.ListLikedYouResponse_Liker {
+	likers := make([]*epv2.ListLikedYouResponse_Liker, 0, len(decisions))
+
 	for _, dec := range decisions {
-		likers = append(likers, &ep.ListLikedYouResponse_Liker{
-			ActorId:       strconv.Itoa(int(dec.AuthorID)),
+		likers = append(likers, &epv2.ListLikedYouResponse_Liker{
+			ActorId:       dec.AuthorID.Int64(),
 			UnixTimestamp: uint64(dec.UpdatedAt.Unix()), // When was the decision last made
+			DecisionType:  toDecisionType(dec.Type),
 		})
 	}
 
-	return &ep.ListLikedYouResponse{
-		Likers: likers,
-	}, nil
+	return likers
 }
 
-func (s *ExploreServer) ListNewLikedYou(ctx context.Context, request *ep.ListLikedYouRequest) (*ep.ListLikedYouResponse, error) {
-	likers := make([]*ep.ListLikedYouResponse_Liker, 0)
+func (s *ExploreServer) CountLikedYou(ctx context.Context, request *epv2.CountLikedYouRequest) (*epv2.CountLikedYouResponse, error) {
+	recipientUserID, err := validateUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
 
-	recipientUserID, err := strconv.Atoi(request.GetRecipientUserId())
+	if err := s.checkActiveUser(ctx, "recipient_user_id", recipientUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	counts, err := s.likesReadModel.GetLikesCountsByProfileId(ctx, recipientUserID)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error counting likes for recipient id: %w", err))
+	}
+
+	response := &epv2.CountLikedYouResponse{}
+
+	// Every like type is reported, the ones without any like too
+	for _, decisionType := range entity.LikeDecisionTypes {
+		response.Count += uint64(counts[decisionType])
+		response.CountsByType = append(response.CountsByType, &epv2.CountLikedYouResponse_CountByType{
+			DecisionType: toDecisionType(decisionType),
+			Count:        uint64(counts[decisionType]),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *ExploreServer) PutDecision(ctx context.Context, request *epv2.PutDecisionRequest) (*epv2.PutDecisionResponse, error) {
+	actorUserId, err := validateUserID("actor_user_id", request.GetActorUserId())
 	if err != nil {
-		return nil, fmt.Errorf("error converting recipient user id string: %w", err)
+		return nil, toStatusError(err)
 	}
 
-	// Ideally we should check that the recipient user id exists first by calling a method to check
-	liked := true
+	recipientUserId, err := validateUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 
-	userDecisionsLiked, err := s.explorerRepository.GetDecisionsForUserId(ctx, recipientUserID, &liked)
+	decisionType, err := decisionTypeOf(request)
 	if err != nil {
-		return nil, fmt.Errorf("error getting user decisions list: %w", err)
-	} else if errors.Is(err, &domainError.DecisionNotFoundErr{}) {
-		// Do nothing
+		return nil, toStatusError(err)
+	}
+
+	// The decision is made by the actor, only the actor (or a service on their behalf) can make it
+	if err := authorize(ctx, actorUserId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Both users must be active, a deactivated user can't like nor be liked
+	if err := s.checkActiveUser(ctx, "actor_user_id", actorUserId); err != nil {
+		return nil, toStatusError(err)
 	}
 
-	recipientDecisionsLiked, err := s.explorerRepository.GetDecisionsForRecipientId(ctx, recipientUserID, &liked)
+	if err := s.checkActiveUser(ctx, "recipient_user_id", recipientUserId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	previous, current, err := s.explorerRepository.UpsertDecision(ctx, actorUserId, recipientUserId, decisionType)
 	if err != nil {
-		return nil, fmt.Errorf("error getting liked decisions for user id: %w", err)
-	} else if errors.Is(err, &domainError.DecisionNotFoundErr{}) {
-		// Do nothing
+		return nil, toStatusError(fmt.Errorf("error putting decision: %w", err))
 	}
 
-	usersLiked := map[int]bool{}
-	for _, userLike := range userDecisionsLiked {
-		usersLiked[int(userLike.RecipientID)] = true
+	// Asked to the repository, the projections of the read model may not have caught up with the decision yet
+	mutualLikes, err := s.explorerRepository.FindMutualLike(ctx, actorUserId, recipientUserId)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error finding mutual like: %w", err))
 	}
 
-	// This is bad and it should not be used in production.
-	// Basically, here we are checking that there isn't a mutual like because
-	// we want to return new users who liked the recipient.
-	for _, recipientLike := range recipientDecisionsLiked {
-		_, ok := usersLiked[int(recipientLike.AuthorID)]
-		if !ok {
-			likers = append(likers, &ep.ListLikedYouResponse_Liker{
-				ActorId: strconv.Itoa(int(recipientLike.AuthorID)),
-			})
+	// Only a like that wasn't there before is notified, liking again the same user is not news.
+	// A like turned into a super like is notified as a super like, the match if any has already been notified.
+	newLike := current.IsLike() && (previous == nil || !previous.IsLike())
+	newSuperLike := current.Type == entity.DecisionTypeSuperLike && previous != nil && previous.Type == entity.DecisionTypeLike
+	matched := newLike && mutualLikes
+
+	if newLike || newSuperLike {
+		blocked, err := s.explorerRepository.IsBlocked(ctx, actorUserId, recipientUserId)
+		if err != nil {
+			return nil, toStatusError(fmt.Errorf("error searching for blocks: %w", err))
+		}
+
+		// The like of a blocked pair is recorded like any other, so the actor can't tell, but never notified
+		if !blocked {
+			s.publishNewLike(ctx, current, matched)
 		}
 	}
 
-	return &ep.ListLikedYouResponse{
-		Likers: likers,
+	// Same for the counters, a decision that doesn't change anything isn't recorded
+	if previous == nil || previous.Type != current.Type {
+		s.decisionRecorder.RecordDecision(current.Type, matched)
+	}
+
+	return &epv2.PutDecisionResponse{
+		MutualLikes: mutualLikes,
 	}, nil
 }
 
-func (s *ExploreServer) CountLikedYou(ctx context.Context, request *ep.CountLikedYouRequest) (*ep.CountLikedYouResponse, error) {
-	recipientUserID, err := strconv.Atoi(request.GetRecipientUserId())
+func (s *ExploreServer) UndoLastDecision(ctx context.Context, request *epv2.UndoLastDecisionRequest) (*epv2.UndoLastDecisionResponse, error) {
+	actorUserID, err := validateUserID("actor_user_id", request.GetActorUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "actor_user_id", actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	change, matchDissolved, err := s.explorerRepository.UndoLastDecision(ctx, actorUserID, time.Now().Add(-s.undoWindow))
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error undoing last decision: %w", err))
+	}
+
+	response := &epv2.UndoLastDecisionResponse{
+		RecipientUserId:    change.RecipientID.Int64(),
+		UndoneDecisionType: toDecisionType(change.Type),
+		MatchDissolved:     matchDissolved,
+	}
+
+	if change.PreviousType != nil {
+		response.RestoredDecisionType = toDecisionType(*change.PreviousType)
+	}
+
+	// Both users have been notified of the match, they are both told it is gone.
+	// A like that didn't make a match isn't retracted, the liker lists are the reference.
+	if matchDissolved {
+		s.publishUnmatch(ctx, change)
+	}
+
+	return response, nil
+}
+
+func (s *ExploreServer) ListMatches(ctx context.Context, request *epv2.ListMatchesRequest) (*epv2.ListMatchesResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "user_id", userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	cursor, err := decodeMatchCursor(request.PaginationToken)
 	if err != nil {
-		return nil, fmt.Errorf("error converting recipient user id string: %w", err)
+		return nil, toStatusError(err)
 	}
 
-	// Ideally we should check that the recipient user id exists first by calling a method to check
-	result := s.explorerRepository.GetLikesCountByProfileId(ctx, recipientUserID)
+	// One more match than the page size is requested to know if there is a next page
+	matches, err := s.likesReadModel.ListMatchesForUserId(ctx, userID, cursor, MatchesPageSize+1)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error getting matches for user id: %w", err))
+	}
+
+	matches, nextPaginationToken := pageOf(matches, MatchesPageSize, matchPosition)
 
-	return &ep.CountLikedYouResponse{
-		Count: uint64(result),
+	responseMatches := make([]*epv2.ListMatchesResponse_Match, 0, len(matches))
+	for _, match := range matches {
+		responseMatches = append(responseMatches, &epv2.ListMatchesResponse_Match{
+			UserId:                 match.UserID.Int64(),
+			MatchedAtUnixTimestamp: uint64(match.MatchedAt.Unix()),
+		})
+	}
+
+	return &epv2.ListMatchesResponse{
+		Matches:             responseMatches,
+		NextPaginationToken: nextPaginationToken,
 	}, nil
 }
 
-func (s *ExploreServer) PutDecision(ctx context.Context, request *ep.PutDecisionRequest) (*ep.PutDecisionResponse, error) {
-	actorUserId, err := strconv.Atoi(request.GetActorUserId())
+func (s *ExploreServer) WatchLikes(request *epv2.WatchLikesRequest, stream epv2.ExploreService_WatchLikesServer) error {
+	recipientUserID, err := validateUserID("recipient_user_id", request.GetRecipientUserId())
 	if err != nil {
-		return nil, fmt.Errorf("error converting actor user id string: %w", err)
+		return toStatusError(err)
+	}
+
+	ctx := stream.Context()
+
+	if err := authorize(ctx, recipientUserID); err != nil {
+		return toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "recipient_user_id", recipientUserID); err != nil {
+		return toStatusError(err)
 	}
 
-	recipientUserId, err := strconv.Atoi(request.GetRecipientUserId())
+	subscription, err := s.likeHub.Subscribe(ctx, recipientUserID, request.GetResumeToken())
 	if err != nil {
-		return nil, fmt.Errorf("error converting recipient user id string: %w", err)
+		return toStatusError(fmt.Errorf("error subscribing to likes: %w", err))
 	}
+	defer subscription.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return toStatusError(ctx.Err())
+		case likeEvent, ok := <-subscription.Events():
+			if !ok {
+				// The subscription has been ended by the hub, i.e. the client is too slow or the server is stopping.
+				// The client can reconnect with the resume token of the last event it received.
+				if err := subscription.Err(); err != nil {
+					return toStatusError(fmt.Errorf("error watching likes: %w", err))
+				}
+
+				return nil
+			}
+
+			if err := stream.Send(toLikeEvent(likeEvent)); err != nil {
+				return err
+			}
+		}
+	}
+}
 
-	// Ideally we should check that both the user ids exists before calling this
-	err = s.explorerRepository.UpdateDecision(ctx, actorUserId, recipientUserId, request.GetLikedRecipient())
+func (s *ExploreServer) BlockUser(ctx context.Context, request *epv2.BlockUserRequest) (*epv2.BlockUserResponse, error) {
+	actorUserID, err := validateUserID("actor_user_id", request.GetActorUserId())
 	if err != nil {
-		return nil, fmt.Errorf("error putting decision: %w", err)
-	} else if errors.Is(err, &domainError.DecisionNotFoundErr{}) {
-		err = s.explorerRepository.CreateDecision(ctx,
-			&entity.Decision{
-				AuthorID:    uint(actorUserId),
-				RecipientID: uint(recipientUserId),
-				Liked:       request.GetLikedRecipient(),
-			})
+		return nil, toStatusError(err)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("error putting decision: %w", err)
+	blockedUserID, err := validateUserID("blocked_user_id", request.GetBlockedUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "actor_user_id", actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// The blocked user may be deactivated, it is blocked for when it comes back
+	if err := s.explorerRepository.BlockUser(ctx, actorUserID, blockedUserID); err != nil {
+		return nil, toStatusError(fmt.Errorf("error blocking user: %w", err))
+	}
+
+	return &epv2.BlockUserResponse{}, nil
+}
+
+func (s *ExploreServer) UnblockUser(ctx context.Context, request *epv2.UnblockUserRequest) (*epv2.UnblockUserResponse, error) {
+	actorUserID, err := validateUserID("actor_user_id", request.GetActorUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	blockedUserID, err := validateUserID("blocked_user_id", request.GetBlockedUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "actor_user_id", actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Only the blocks of the actor are lifted, a block made by the other user stays
+	if err := s.explorerRepository.UnblockUser(ctx, actorUserID, blockedUserID); err != nil {
+		return nil, toStatusError(fmt.Errorf("error unblocking user: %w", err))
+	}
+
+	return &epv2.UnblockUserResponse{}, nil
+}
+
+func (s *ExploreServer) ReportUser(ctx context.Context, request *epv2.ReportUserRequest) (*epv2.ReportUserResponse, error) {
+	actorUserID, err := validateUserID("actor_user_id", request.GetActorUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	reportedUserID, err := validateUserID("reported_user_id", request.GetReportedUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	reason, err := toReportReason(request.GetReason())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.checkActiveUser(ctx, "actor_user_id", actorUserID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	report := &entity.Report{
+		ReporterID: actorUserID,
+		ReportedID: reportedUserID,
+		Reason:     reason,
+		Details:    request.GetDetails(),
+	}
+
+	if err := s.explorerRepository.CreateReport(ctx, report); err != nil {
+		return nil, toStatusError(fmt.Errorf("error reporting user: %w", err))
+	}
+
+	return &epv2.ReportUserResponse{}, nil
+}
+
+func (s *ExploreServer) ListDecisionHistory(ctx context.Context, request *epv2.ListDecisionHistoryRequest) (*epv2.ListDecisionHistoryResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	query := repository.DecisionEventsQuery{UserID: userID}
+
+	if request.OtherUserId != nil {
+		if query.OtherUserID, err = validateUserID("other_user_id", request.GetOtherUserId()); err != nil {
+			return nil, toStatusError(err)
 		}
 	}
 
-	mutualLikes := s.explorerRepository.FindMutualLike(ctx, actorUserId, recipientUserId)
+	// The history is for the support team, the users export their own data with ExportUserData
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, toStatusError(err)
+	}
 
-	return &ep.PutDecisionResponse{
-		MutualLikes: mutualLikes,
+	cursor, err := decodeDecisionEventCursor(request.PaginationToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// The history of the deactivated, deleted or unknown users is returned too, there is no active user check.
+	// One more event than the page size is requested to know if there is a next page.
+	decisionEvents, err := s.explorerRepository.ListDecisionEvents(ctx, query, cursor, DecisionHistoryPageSize+1)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error listing decision events: %w", err))
+	}
+
+	decisionEvents, nextPaginationToken := pageOf(decisionEvents, DecisionHistoryPageSize, decisionEventPosition)
+
+	responseEvents := make([]*epv2.ListDecisionHistoryResponse_Event, 0, len(decisionEvents))
+	for _, decisionEvent := range decisionEvents {
+		responseEvents = append(responseEvents, toDecisionHistoryEvent(decisionEvent))
+	}
+
+	return &epv2.ListDecisionHistoryResponse{
+		Events:              responseEvents,
+		NextPaginationToken: nextPaginationToken,
 	}, nil
 }
+
+// Checks that the user of the request field exists and is active, the users table is the source of truth
+func (s *ExploreServer) checkActiveUser(ctx context.Context, field string, userID entity.UserID) error {
+	user, err := s.explorerRepository.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error checking the user of %s: %w", field, err)
+	}
+
+	if user.IsDeactivated() {
+		return fmt.Errorf("error checking the user of %s: %w", field, domainError.NewUserDeactivatedErr())
+	}
+
+	return nil
+}
+
+// Publishes a new like or super like to the recipient, or the match to both users when the like made a match
+func (s *ExploreServer) publishNewLike(ctx context.Context, decision *entity.Decision, matched bool) {
+	if !matched {
+		eventType := event.LikeEventTypeLike
+		if decision.Type == entity.DecisionTypeSuperLike {
+			eventType = event.LikeEventTypeSuperLike
+		}
+
+		s.likeHub.Publish(ctx, event.LikeEvent{
+			Type:        eventType,
+			ActorID:     decision.AuthorID,
+			RecipientID: decision.RecipientID,
+			OccurredAt:  decision.UpdatedAt,
+		})
+
+		return
+	}
+
+	s.likeHub.Publish(ctx, event.LikeEvent{
+		Type:        event.LikeEventTypeMatch,
+		ActorID:     decision.AuthorID,
+		RecipientID: decision.RecipientID,
+		OccurredAt:  decision.UpdatedAt,
+	})
+
+	s.likeHub.Publish(ctx, event.LikeEvent{
+		Type:        event.LikeEventTypeMatch,
+		ActorID:     decision.RecipientID,
+		RecipientID: decision.AuthorID,
+		OccurredAt:  decision.UpdatedAt,
+	})
+}
+
+func (s *ExploreServer) publishUnmatch(ctx context.Context, change *entity.DecisionChange) {
+	s.likeHub.Publish(ctx, event.LikeEvent{
+		Type:        event.LikeEventTypeUnmatch,
+		ActorID:     change.AuthorID,
+		RecipientID: change.RecipientID,
+		OccurredAt:  *change.UndoneAt,
+	})
+
+	s.likeHub.Publish(ctx, event.LikeEvent{
+		Type:        event.LikeEventTypeUnmatch,
+		ActorID:     change.RecipientID,
+		RecipientID: change.AuthorID,
+		OccurredAt:  *change.UndoneAt,
+	})
+}
+
+func toReportReason(reason epv2.ReportUserRequest_Reason) (entity.ReportReason, error) {
+	switch reason {
+	case epv2.ReportUserRequest_REASON_SPAM:
+		return entity.ReportReasonSpam, nil
+	case epv2.ReportUserRequest_REASON_FAKE_PROFILE:
+		return entity.ReportReasonFakeProfile, nil
+	case epv2.ReportUserRequest_REASON_INAPPROPRIATE_CONTENT:
+		return entity.ReportReasonInappropriateContent, nil
+	case epv2.ReportUserRequest_REASON_HARASSMENT:
+		return entity.ReportReasonHarassment, nil
+	case epv2.ReportUserRequest_REASON_OTHER:
+		return entity.ReportReasonOther, nil
+	default:
+		return "", domainError.NewInvalidArgumentErr("reason", "must be a known reason")
+	}
+}
+
+func toDecisionHistoryEvent(decisionEvent entity.DecisionEvent) *epv2.ListDecisionHistoryResponse_Event {
+	responseEvent := &epv2.ListDecisionHistoryResponse_Event{
+		Id:                      decisionEvent.ID,
+		AuthorUserId:            decisionEvent.AuthorID.Int64(),
+		RecipientUserId:         decisionEvent.RecipientID.Int64(),
+		RequestId:               decisionEvent.RequestID,
+		OccurredAtUnixTimestamp: uint64(decisionEvent.OccurredAt.Unix()),
+	}
+
+	if decisionEvent.OldType != nil {
+		responseEvent.OldDecisionType = toDecisionType(*decisionEvent.OldType)
+	}
+
+	if decisionEvent.NewType != nil {
+		responseEvent.NewDecisionType = toDecisionType(*decisionEvent.NewType)
+	}
+
+	switch decisionEvent.Cause {
+	case entity.DecisionEventCausePut:
+		responseEvent.Cause = epv2.ListDecisionHistoryResponse_Event_CAUSE_PUT
+	case entity.DecisionEventCauseUndo:
+		responseEvent.Cause = epv2.ListDecisionHistoryResponse_Event_CAUSE_UNDO
+	}
+
+	return responseEvent
+}
+
+func toLikeEvent(likeEvent event.LikeEvent) *epv2.LikeEvent {
+	eventType := epv2.LikeEvent_TYPE_LIKE
+	switch likeEvent.Type {
+	case event.LikeEventTypeMatch:
+		eventType = epv2.LikeEvent_TYPE_MATCH
+	case event.LikeEventTypeSuperLike:
+		eventType = epv2.LikeEvent_TYPE_SUPER_LIKE
+	case event.LikeEventTypeUnmatch:
+		eventType = epv2.LikeEvent_TYPE_UNMATCH
+	}
+
+	return &epv2.LikeEvent{
+		Type:          eventType,
+		ActorId:       likeEvent.ActorID.Int64(),
+		UnixTimestamp: uint64(likeEvent.OccurredAt.Unix()),
+		ResumeToken:   likeEvent.Cursor,
+	}
+}
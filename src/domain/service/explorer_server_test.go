@@ -7,20 +7,49 @@ import (
 
 	"context"
 
+	"github.com/lokker96/grpc_project/domain/auth"
 	"github.com/lokker96/grpc_project/domain/entity"
-	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	event_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/event"
 	repository_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/repository"
 	"github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// Context of a request authenticated as the user
+func asUser(userID entity.UserID) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{
+		Subject: userID.String(),
+		UserID:  userID,
+	})
+}
+
+// Repository where every user exists and is active, for the tests that aren't about the users
+func activeUsersRepositoryMock() *repository_mock.MockExplorerRepository {
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	repositoryMock.On("GetUser", mock.Anything, mock.Anything).Maybe().Return(func(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: userID}, nil
+	})
+
+	return repositoryMock
+}
+
 type inputData struct {
 	ctx     context.Context
-	request *explore.ListLikedYouRequest
+	request *explorev2.ListLikedYouRequest
 }
 
 type expectation struct {
-	response *explore.ListLikedYouResponse
+	response *explorev2.ListLikedYouResponse
 	err      error
 }
 
@@ -43,17 +72,17 @@ func Test_ListLikedYou(t *testing.T) {
 		// User 1 likers: user id 2
 		{
 			inputData: inputData{
-				ctx: context.Background(),
-				request: &explore.ListLikedYouRequest{
-					RecipientUserId: "1",
+				ctx: asUser(1),
+				request: &explorev2.ListLikedYouRequest{
+					RecipientUserId: 1,
 					PaginationToken: nil,
 				},
 			},
 			expectations: expectation{
-				response: &explore.ListLikedYouResponse{
-					Likers: []*explore.ListLikedYouResponse_Liker{
+				response: &explorev2.ListLikedYouResponse{
+					Likers: []*explorev2.ListLikedYouResponse_Liker{
 						{
-							ActorId:       "2",
+							ActorId:       2,
 							UnixTimestamp: uint64(nowTime.Unix()),
 						},
 					},
@@ -67,7 +96,7 @@ func Test_ListLikedYou(t *testing.T) {
 						ID:          1,
 						AuthorID:    2,
 						RecipientID: 1,
-						Liked:       true,
+						Type:        entity.DecisionTypeLike,
 						CreatedAt:   nowTime,
 						UpdatedAt:   nowTime,
 					},
@@ -78,15 +107,15 @@ func Test_ListLikedYou(t *testing.T) {
 		// User 1 likers returns error
 		{
 			inputData: inputData{
-				ctx: context.Background(),
-				request: &explore.ListLikedYouRequest{
-					RecipientUserId: "1",
+				ctx: asUser(1),
+				request: &explorev2.ListLikedYouRequest{
+					RecipientUserId: 1,
 					PaginationToken: nil,
 				},
 			},
 			expectations: expectation{
 				response: nil,
-				err:      errors.New("error getting liked decisions for recipient id: Error executing query"),
+				err:      status.Error(codes.Internal, "internal error"),
 			},
 			mocksData: mockData{
 				dbDecisions: nil,
@@ -102,13 +131,13 @@ func Test_ListLikedYou(t *testing.T) {
 }
 
 func listLikedYou_tester_func(t *testing.T, testCase testCaseData) {
-	repositoryMock := &repository_mock.MockExplorerRepository{}
+	repositoryMock := activeUsersRepositoryMock()
 
 	repositoryMock.
-		On("GetDecisionsForRecipientId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*bool")).
+		On("ListLikersForRecipientId", mock.Anything, mock.AnythingOfType("entity.UserID"), mock.AnythingOfType("repository.LikersQuery"), mock.AnythingOfType("*repository.DecisionCursor"), LikersPageSize+1).
 		Once().Return(testCase.mocksData.dbDecisions, testCase.mocksData.dbError)
 
-	explorerService := NewExplorerServer(repositoryMock)
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
 
 	response, err := explorerService.ListLikedYou(testCase.inputData.ctx, testCase.inputData.request)
 
@@ -136,3 +165,1124 @@ func listLikedYou_tester_func(t *testing.T, testCase testCaseData) {
 
 	repositoryMock.AssertExpectations(t)
 }
+
+func Test_ListLikedYou_Pagination(t *testing.T) {
+	nowTime := time.Now()
+
+	// One decision more than the page size means there is a next page
+	dbDecisions := make([]entity.Decision, 0, LikersPageSize+1)
+	for i := 0; i < LikersPageSize+1; i++ {
+		dbDecisions = append(dbDecisions, entity.Decision{
+			ID:          int64(LikersPageSize + 1 - i),
+			AuthorID:    entity.UserID(i + 2),
+			RecipientID: 1,
+			Type:        entity.DecisionTypeLike,
+			CreatedAt:   nowTime,
+			UpdatedAt:   nowTime.Add(-time.Duration(i) * time.Second),
+		})
+	}
+
+	lastDecision := dbDecisions[LikersPageSize-1]
+	expectedCursor := &repository.DecisionCursor{
+		Rank:      0,
+		UpdatedAt: time.Unix(0, lastDecision.UpdatedAt.UnixNano()),
+		ID:        lastDecision.ID,
+	}
+
+	repositoryMock := activeUsersRepositoryMock()
+
+	repositoryMock.
+		On("ListLikersForRecipientId", mock.Anything, entity.UserID(1), repository.LikersQuery{}, (*repository.DecisionCursor)(nil), LikersPageSize+1).
+		Once().Return(dbDecisions, nil)
+
+	repositoryMock.
+		On("ListLikersForRecipientId", mock.Anything, entity.UserID(1), repository.LikersQuery{}, expectedCursor, LikersPageSize+1).
+		Once().Return([]entity.Decision{}, nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	response, err := explorerService.ListLikedYou(asUser(1), &explorev2.ListLikedYouRequest{
+		RecipientUserId: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Likers), LikersPageSize)
+	assert.Equal(t, response.Likers[LikersPageSize-1].ActorId, lastDecision.AuthorID.Int64())
+
+	if response.NextPaginationToken == nil {
+		t.Fatal("expected a next pagination token")
+	}
+
+	// The token must point right after the last liker of the first page
+	response, err = explorerService.ListLikedYou(asUser(1), &explorev2.ListLikedYouRequest{
+		RecipientUserId: 1,
+		PaginationToken: response.NextPaginationToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Likers), 0)
+	assert.Equal(t, response.NextPaginationToken, (*string)(nil))
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_ListLikedYou_InvalidPaginationToken(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	invalidToken := "not a token"
+	_, err := explorerService.ListLikedYou(asUser(1), &explorev2.ListLikedYouRequest{
+		RecipientUserId: 1,
+		PaginationToken: &invalidToken,
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid pagination token")
+	}
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_ListLikedYou_FilterAndOrder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		request       *explorev2.ListLikedYouRequest
+		expectedQuery repository.LikersQuery
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "Super likes first by default",
+			request:       &explorev2.ListLikedYouRequest{RecipientUserId: 1},
+			expectedQuery: repository.LikersQuery{Order: repository.LikersOrderRanked},
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "Only the super likes, most recent first",
+			request: &explorev2.ListLikedYouRequest{
+				RecipientUserId: 1,
+				DecisionTypes:   []explorev2.DecisionType{explorev2.DecisionType_DECISION_TYPE_SUPER_LIKE},
+				Order:           explorev2.ListLikedYouRequest_ORDER_MOST_RECENT,
+			},
+			expectedQuery: repository.LikersQuery{
+				Types: []entity.DecisionType{entity.DecisionTypeSuperLike},
+				Order: repository.LikersOrderMostRecent,
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "The passes can't be listed",
+			request: &explorev2.ListLikedYouRequest{
+				RecipientUserId: 1,
+				DecisionTypes:   []explorev2.DecisionType{explorev2.DecisionType_DECISION_TYPE_PASS},
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := activeUsersRepositoryMock()
+
+			if testCase.expectedCode == codes.OK {
+				repositoryMock.
+					On("ListNewLikersForRecipientId", mock.Anything, entity.UserID(1), testCase.expectedQuery, (*repository.DecisionCursor)(nil), LikersPageSize+1).
+					Once().Return([]entity.Decision{}, nil)
+			}
+
+			explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+			_, err := explorerService.ListNewLikedYou(asUser(1), testCase.request)
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CountLikedYou_CountsByType(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	// Only the super likes have been counted, the likes are reported anyway
+	repositoryMock.
+		On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).
+		Once().Return(map[entity.DecisionType]int64{entity.DecisionTypeSuperLike: 2}, nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	response, err := explorerService.CountLikedYou(asUser(1), &explorev2.CountLikedYouRequest{RecipientUserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, response.Count, uint64(2))
+	assert.Equal(t, len(response.CountsByType), 2)
+	assert.Equal(t, response.CountsByType[0].DecisionType, explorev2.DecisionType_DECISION_TYPE_SUPER_LIKE)
+	assert.Equal(t, response.CountsByType[0].Count, uint64(2))
+	assert.Equal(t, response.CountsByType[1].DecisionType, explorev2.DecisionType_DECISION_TYPE_LIKE)
+	assert.Equal(t, response.CountsByType[1].Count, uint64(0))
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_CountLikedYou_ReadsFromTheLikesReadModel(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	// The projections serve the counts in place of the repository
+	readModelMock := &repository_mock.MockExplorerRepository{}
+	readModelMock.
+		On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).
+		Once().Return(map[entity.DecisionType]int64{entity.DecisionTypeLike: 3}, nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+	explorerService.SetLikesReadModel(readModelMock)
+
+	response, err := explorerService.CountLikedYou(asUser(1), &explorev2.CountLikedYouRequest{RecipientUserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, response.Count, uint64(3))
+
+	repositoryMock.AssertNotCalled(t, "GetLikesCountsByProfileId", mock.Anything, mock.Anything)
+	readModelMock.AssertExpectations(t)
+}
+
+type recordedDecision struct {
+	decisionType entity.DecisionType
+	matched      bool
+}
+
+func Test_PutDecision(t *testing.T) {
+	nowTime := time.Now()
+
+	testCases := []struct {
+		decisionType      entity.DecisionType
+		previous          *entity.Decision
+		mutualLikes       bool
+		blocked           bool
+		expectedPublished []event.LikeEvent
+		expectedRecorded  *recordedDecision
+	}{
+		// New like, the recipient is notified
+		{
+			decisionType: entity.DecisionTypeLike,
+			previous:     nil,
+			mutualLikes:  false,
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeLike, ActorID: 1, RecipientID: 2, OccurredAt: nowTime},
+			},
+			expectedRecorded: &recordedDecision{decisionType: entity.DecisionTypeLike, matched: false},
+		},
+		// New like after a pass that makes a match, both users are notified
+		{
+			decisionType: entity.DecisionTypeLike,
+			previous:     &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypePass},
+			mutualLikes:  true,
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeMatch, ActorID: 1, RecipientID: 2, OccurredAt: nowTime},
+				{Type: event.LikeEventTypeMatch, ActorID: 2, RecipientID: 1, OccurredAt: nowTime},
+			},
+			expectedRecorded: &recordedDecision{decisionType: entity.DecisionTypeLike, matched: true},
+		},
+		// Liking again the same user is neither notified nor recorded
+		{
+			decisionType:      entity.DecisionTypeLike,
+			previous:          &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeLike},
+			mutualLikes:       true,
+			expectedPublished: nil,
+			expectedRecorded:  nil,
+		},
+		// A pass is never notified
+		{
+			decisionType:      entity.DecisionTypePass,
+			previous:          nil,
+			mutualLikes:       false,
+			expectedPublished: nil,
+			expectedRecorded:  &recordedDecision{decisionType: entity.DecisionTypePass, matched: false},
+		},
+		// A pass after a like is recorded, even if the recipient still likes the actor
+		{
+			decisionType:      entity.DecisionTypePass,
+			previous:          &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeLike},
+			mutualLikes:       false,
+			expectedPublished: nil,
+			expectedRecorded:  &recordedDecision{decisionType: entity.DecisionTypePass, matched: false},
+		},
+		// A new like between blocked users is recorded but never notified
+		{
+			decisionType:      entity.DecisionTypeLike,
+			previous:          nil,
+			mutualLikes:       false,
+			blocked:           true,
+			expectedPublished: nil,
+			expectedRecorded:  &recordedDecision{decisionType: entity.DecisionTypeLike, matched: false},
+		},
+		// New super like, the recipient is notified of a super like
+		{
+			decisionType: entity.DecisionTypeSuperLike,
+			previous:     nil,
+			mutualLikes:  false,
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeSuperLike, ActorID: 1, RecipientID: 2, OccurredAt: nowTime},
+			},
+			expectedRecorded: &recordedDecision{decisionType: entity.DecisionTypeSuperLike, matched: false},
+		},
+		// New super like that makes a match, a match is a match whatever the like
+		{
+			decisionType: entity.DecisionTypeSuperLike,
+			previous:     nil,
+			mutualLikes:  true,
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeMatch, ActorID: 1, RecipientID: 2, OccurredAt: nowTime},
+				{Type: event.LikeEventTypeMatch, ActorID: 2, RecipientID: 1, OccurredAt: nowTime},
+			},
+			expectedRecorded: &recordedDecision{decisionType: entity.DecisionTypeSuperLike, matched: true},
+		},
+		// A like turned into a super like is notified, the match was already there
+		{
+			decisionType: entity.DecisionTypeSuperLike,
+			previous:     &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeLike},
+			mutualLikes:  true,
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeSuperLike, ActorID: 1, RecipientID: 2, OccurredAt: nowTime},
+			},
+			expectedRecorded: &recordedDecision{decisionType: entity.DecisionTypeSuperLike, matched: false},
+		},
+		// A super like turned into a like is recorded but not notified
+		{
+			decisionType:      entity.DecisionTypeLike,
+			previous:          &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeSuperLike},
+			mutualLikes:       false,
+			expectedPublished: nil,
+			expectedRecorded:  &recordedDecision{decisionType: entity.DecisionTypeLike, matched: false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		repositoryMock := activeUsersRepositoryMock()
+		likeHubMock := &event_mock.MockLikeHub{}
+		decisionRecorderMock := &event_mock.MockDecisionRecorder{}
+
+		repositoryMock.
+			On("UpsertDecision", mock.Anything, entity.UserID(1), entity.UserID(2), testCase.decisionType).
+			Once().Return(testCase.previous, &entity.Decision{ID: 1, AuthorID: 1, RecipientID: 2, Type: testCase.decisionType, UpdatedAt: nowTime}, nil)
+
+		repositoryMock.
+			On("FindMutualLike", mock.Anything, entity.UserID(1), entity.UserID(2)).
+			Once().Return(testCase.mutualLikes, nil)
+
+		repositoryMock.
+			On("IsBlocked", mock.Anything, entity.UserID(1), entity.UserID(2)).
+			Maybe().Return(testCase.blocked, nil)
+
+		for _, published := range testCase.expectedPublished {
+			likeHubMock.On("Publish", mock.Anything, published).Once().Return()
+		}
+
+		if testCase.expectedRecorded != nil {
+			decisionRecorderMock.On("RecordDecision", testCase.expectedRecorded.decisionType, testCase.expectedRecorded.matched).Once().Return()
+		}
+
+		explorerService := NewExplorerServer(repositoryMock, likeHubMock, decisionRecorderMock)
+
+		response, err := explorerService.PutDecision(asUser(1), &explorev2.PutDecisionRequest{
+			ActorUserId:     1,
+			RecipientUserId: 2,
+			DecisionType:    toDecisionType(testCase.decisionType),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, response.MutualLikes, testCase.mutualLikes)
+
+		repositoryMock.AssertExpectations(t)
+		likeHubMock.AssertExpectations(t)
+		decisionRecorderMock.AssertExpectations(t)
+	}
+}
+
+// Server stream that records the events sent to the client
+type likeEventsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*explorev2.LikeEvent
+}
+
+func (s *likeEventsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *likeEventsStream) Send(likeEvent *explorev2.LikeEvent) error {
+	s.sent = append(s.sent, likeEvent)
+	return nil
+}
+
+func Test_WatchLikes(t *testing.T) {
+	nowTime := time.Now()
+
+	testCases := []struct {
+		resumeToken   *string
+		events        []event.LikeEvent
+		subscribeErr  error
+		endErr        error
+		expectedCode  codes.Code
+		expectedTypes []explorev2.LikeEvent_Type
+	}{
+		// The events are sent until the hub ends the subscription
+		{
+			events: []event.LikeEvent{
+				{Type: event.LikeEventTypeLike, ActorID: 2, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.1"},
+				{Type: event.LikeEventTypeMatch, ActorID: 3, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.2"},
+			},
+			expectedCode:  codes.OK,
+			expectedTypes: []explorev2.LikeEvent_Type{explorev2.LikeEvent_TYPE_LIKE, explorev2.LikeEvent_TYPE_MATCH},
+		},
+		// A slow consumer is dropped with ResourceExhausted after the buffered events
+		{
+			events: []event.LikeEvent{
+				{Type: event.LikeEventTypeLike, ActorID: 2, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.1"},
+			},
+			endErr:        domainError.NewSlowConsumerErr(),
+			expectedCode:  codes.ResourceExhausted,
+			expectedTypes: []explorev2.LikeEvent_Type{explorev2.LikeEvent_TYPE_LIKE},
+		},
+		// The resume token is too old
+		{
+			resumeToken:  proto.String("a.1"),
+			subscribeErr: domainError.NewResumeCursorExpiredErr(),
+			expectedCode: codes.OutOfRange,
+		},
+	}
+
+	for _, testCase := range testCases {
+		likeHubMock := &event_mock.MockLikeHub{}
+		subscriptionMock := &event_mock.MockLikeSubscription{}
+
+		if testCase.subscribeErr != nil {
+			likeHubMock.
+				On("Subscribe", mock.Anything, entity.UserID(1), *testCase.resumeToken).
+				Once().Return(nil, testCase.subscribeErr)
+		} else {
+			events := make(chan event.LikeEvent, len(testCase.events))
+			for _, likeEvent := range testCase.events {
+				events <- likeEvent
+			}
+			close(events)
+
+			likeHubMock.
+				On("Subscribe", mock.Anything, entity.UserID(1), "").
+				Once().Return(subscriptionMock, nil)
+
+			subscriptionMock.On("Events").Return((<-chan event.LikeEvent)(events))
+			subscriptionMock.On("Err").Once().Return(testCase.endErr)
+			subscriptionMock.On("Close").Once().Return()
+		}
+
+		explorerService := NewExplorerServer(activeUsersRepositoryMock(), likeHubMock, &event_mock.MockDecisionRecorder{})
+
+		stream := &likeEventsStream{ctx: asUser(1)}
+
+		err := explorerService.WatchLikes(&explorev2.WatchLikesRequest{
+			RecipientUserId: 1,
+			ResumeToken:     testCase.resumeToken,
+		}, stream)
+
+		assert.Equal(t, status.Code(err), testCase.expectedCode)
+		assert.Equal(t, len(stream.sent), len(testCase.expectedTypes))
+
+		for i, sent := range stream.sent {
+			assert.Equal(t, sent.Type, testCase.expectedTypes[i])
+			assert.Equal(t, sent.ActorId, testCase.events[i].ActorID.Int64())
+			assert.Equal(t, sent.ResumeToken, testCase.events[i].Cursor)
+		}
+
+		likeHubMock.AssertExpectations(t)
+		subscriptionMock.AssertExpectations(t)
+	}
+}
+
+func Test_InvalidUserIdsReturnInvalidArgument(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"ListLikedYou": func() error {
+			_, err := explorerService.ListLikedYou(ctx, &explorev2.ListLikedYouRequest{RecipientUserId: -1})
+			return err
+		},
+		"ListNewLikedYou": func() error {
+			_, err := explorerService.ListNewLikedYou(ctx, &explorev2.ListLikedYouRequest{RecipientUserId: -1})
+			return err
+		},
+		"CountLikedYou": func() error {
+			_, err := explorerService.CountLikedYou(ctx, &explorev2.CountLikedYouRequest{RecipientUserId: -1})
+			return err
+		},
+		"PutDecision": func() error {
+			_, err := explorerService.PutDecision(ctx, &explorev2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: -1})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		st, ok := status.FromError(call())
+		if !ok {
+			t.Fatalf("%s: expected a gRPC status error", name)
+		}
+
+		assert.Equal(t, st.Code(), codes.InvalidArgument)
+
+		if len(st.Details()) != 1 {
+			t.Fatalf("%s: expected the bad request details", name)
+		}
+
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		if !ok {
+			t.Fatalf("%s: expected the bad request details", name)
+		}
+
+		assert.Equal(t, badRequest.FieldViolations[0].Field, "recipient_user_id")
+	}
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_RepositoryErrorsAreMappedToStatusCodes(t *testing.T) {
+	testCases := map[error]codes.Code{
+		domainError.NewUserNotFoundErr():                                        codes.NotFound,
+		domainError.NewDecisionNotFoundErr():                                    codes.NotFound,
+		domainError.NewUndoWindowExpiredErr():                                   codes.FailedPrecondition,
+		domainError.NewDatabaseUnavailableErr(errors.New("connection refused")): codes.Unavailable,
+		domainError.NewDatabaseErr(errors.New("syntax error")):                  codes.Internal,
+		domainError.NewDatabaseErr(context.DeadlineExceeded):                    codes.DeadlineExceeded,
+		errors.New("unexpected"):                                                codes.Internal,
+	}
+
+	for repositoryErr, expectedCode := range testCases {
+		repositoryMock := activeUsersRepositoryMock()
+
+		repositoryMock.
+			On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).
+			Once().Return(nil, repositoryErr)
+
+		explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+		_, err := explorerService.CountLikedYou(asUser(1), &explorev2.CountLikedYouRequest{RecipientUserId: 1})
+
+		assert.Equal(t, status.Code(err), expectedCode)
+
+		// The failures of the server are logged, the client only gets a generic message
+		switch expectedCode {
+		case codes.Unavailable:
+			assert.Equal(t, status.Convert(err).Message(), "the database is unavailable, retry later")
+		case codes.Internal:
+			assert.Equal(t, status.Convert(err).Message(), "internal error")
+		}
+
+		repositoryMock.AssertExpectations(t)
+	}
+}
+
+func Test_UnknownAndDeactivatedUsersAreRejected(t *testing.T) {
+	deactivatedAt := time.Now()
+
+	testCases := []struct {
+		name         string
+		user         *entity.User
+		userErr      error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Unknown user",
+			userErr:      domainError.NewUserNotFoundErr(),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Deactivated user",
+			user:         &entity.User{ID: 2, DeactivatedAt: &deactivatedAt},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := &repository_mock.MockExplorerRepository{}
+
+			repositoryMock.On("GetUser", mock.Anything, entity.UserID(1)).Return(&entity.User{ID: 1}, nil)
+			repositoryMock.On("GetUser", mock.Anything, entity.UserID(2)).Return(testCase.user, testCase.userErr)
+
+			explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+			// The decision on the recipient is never recorded
+			_, err := explorerService.PutDecision(asUser(1), &explorev2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 2, LikedRecipient: true})
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			// Nor can the user list its likes
+			_, err = explorerService.ListLikedYou(asUser(2), &explorev2.ListLikedYouRequest{RecipientUserId: 2})
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			_, err = explorerService.CountLikedYou(asUser(2), &explorev2.CountLikedYouRequest{RecipientUserId: 2})
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ListMatches(t *testing.T) {
+	nowTime := time.Now()
+
+	testCases := []struct {
+		request       *explorev2.ListMatchesRequest
+		dbMatches     []entity.Match
+		dbError       error
+		expectedIds   []int64
+		expectedCode  codes.Code
+		expectedToken bool
+	}{
+		// User 1 matched with users 2 and 4
+		{
+			request: &explorev2.ListMatchesRequest{UserId: 1},
+			dbMatches: []entity.Match{
+				{UserID: 4, MatchedAt: nowTime},
+				{UserID: 2, MatchedAt: nowTime.Add(-time.Hour)},
+			},
+			expectedIds:  []int64{4, 2},
+			expectedCode: codes.OK,
+		},
+		// User 1 has no matches
+		{
+			request:      &explorev2.ListMatchesRequest{UserId: 1},
+			dbMatches:    []entity.Match{},
+			expectedIds:  []int64{},
+			expectedCode: codes.OK,
+		},
+		// The database is not reachable
+		{
+			request:      &explorev2.ListMatchesRequest{UserId: 1},
+			dbError:      domainError.NewDatabaseUnavailableErr(errors.New("connection refused")),
+			expectedCode: codes.Unavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		repositoryMock := activeUsersRepositoryMock()
+
+		repositoryMock.
+			On("ListMatchesForUserId", mock.Anything, entity.UserID(1), (*repository.MatchCursor)(nil), MatchesPageSize+1).
+			Once().Return(testCase.dbMatches, testCase.dbError)
+
+		explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+		response, err := explorerService.ListMatches(asUser(1), testCase.request)
+
+		assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+		if err == nil {
+			assert.Equal(t, len(response.Matches), len(testCase.expectedIds))
+
+			for i, match := range response.Matches {
+				assert.Equal(t, match.UserId, testCase.expectedIds[i])
+				assert.Equal(t, match.MatchedAtUnixTimestamp, uint64(testCase.dbMatches[i].MatchedAt.Unix()))
+			}
+
+			assert.Equal(t, response.NextPaginationToken, (*string)(nil))
+		}
+
+		repositoryMock.AssertExpectations(t)
+	}
+}
+
+func Test_ListMatches_Pagination(t *testing.T) {
+	nowTime := time.Now()
+
+	dbMatches := make([]entity.Match, 0, MatchesPageSize+1)
+	for i := 0; i < MatchesPageSize+1; i++ {
+		dbMatches = append(dbMatches, entity.Match{
+			UserID:    entity.UserID(i + 2),
+			MatchedAt: nowTime.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+
+	lastMatch := dbMatches[MatchesPageSize-1]
+	expectedCursor := &repository.MatchCursor{
+		MatchedAt: time.Unix(0, lastMatch.MatchedAt.UnixNano()),
+		UserID:    lastMatch.UserID,
+	}
+
+	repositoryMock := activeUsersRepositoryMock()
+
+	repositoryMock.
+		On("ListMatchesForUserId", mock.Anything, entity.UserID(1), (*repository.MatchCursor)(nil), MatchesPageSize+1).
+		Once().Return(dbMatches, nil)
+
+	repositoryMock.
+		On("ListMatchesForUserId", mock.Anything, entity.UserID(1), expectedCursor, MatchesPageSize+1).
+		Once().Return([]entity.Match{}, nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	response, err := explorerService.ListMatches(asUser(1), &explorev2.ListMatchesRequest{UserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Matches), MatchesPageSize)
+
+	if response.NextPaginationToken == nil {
+		t.Fatal("expected a next pagination token")
+	}
+
+	response, err = explorerService.ListMatches(asUser(1), &explorev2.ListMatchesRequest{
+		UserId:          1,
+		PaginationToken: response.NextPaginationToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Matches), 0)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_ListMatches_InvalidUserId(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	_, err := explorerService.ListMatches(asUser(1), &explorev2.ListMatchesRequest{UserId: -1})
+
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_CallersCanOnlyAccessTheirOwnData(t *testing.T) {
+	testCases := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{
+			name:         "The user itself",
+			ctx:          asUser(1),
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Another user",
+			ctx:          asUser(2),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "A service on behalf of the user",
+			ctx: auth.NewContext(context.Background(), &auth.Principal{
+				Subject: "matching-service",
+				Roles:   []auth.Role{auth.RoleService},
+			}),
+			expectedCode: codes.OK,
+		},
+		{
+			name: "An admin",
+			ctx: auth.NewContext(context.Background(), &auth.Principal{
+				Subject: "3",
+				UserID:  3,
+				Roles:   []auth.Role{auth.RoleAdmin},
+			}),
+			expectedCode: codes.OK,
+		},
+		{
+			name: "A principal without user nor role",
+			ctx: auth.NewContext(context.Background(), &auth.Principal{
+				Subject: "matching-service",
+			}),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "No principal",
+			ctx:          context.Background(),
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, testCase := range testCases {
+		repositoryMock := activeUsersRepositoryMock()
+
+		repositoryMock.On("ListLikersForRecipientId", mock.Anything, entity.UserID(1), mock.Anything, mock.Anything, mock.Anything).Maybe().Return([]entity.Decision{}, nil)
+		repositoryMock.On("ListNewLikersForRecipientId", mock.Anything, entity.UserID(1), mock.Anything, mock.Anything, mock.Anything).Maybe().Return([]entity.Decision{}, nil)
+		repositoryMock.On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).Maybe().Return(map[entity.DecisionType]int64{}, nil)
+		repositoryMock.On("UpsertDecision", mock.Anything, entity.UserID(1), entity.UserID(2), entity.DecisionTypePass).Maybe().Return(nil, &entity.Decision{AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypePass}, nil)
+		repositoryMock.On("FindMutualLike", mock.Anything, entity.UserID(1), entity.UserID(2)).Maybe().Return(false, nil)
+		repositoryMock.On("ListMatchesForUserId", mock.Anything, entity.UserID(1), mock.Anything, mock.Anything).Maybe().Return([]entity.Match{}, nil)
+
+		decisionRecorderMock := &event_mock.MockDecisionRecorder{}
+		decisionRecorderMock.On("RecordDecision", entity.DecisionTypePass, false).Maybe().Return()
+
+		explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, decisionRecorderMock)
+
+		calls := map[string]func() error{
+			"ListLikedYou": func() error {
+				_, err := explorerService.ListLikedYou(testCase.ctx, &explorev2.ListLikedYouRequest{RecipientUserId: 1})
+				return err
+			},
+			"ListNewLikedYou": func() error {
+				_, err := explorerService.ListNewLikedYou(testCase.ctx, &explorev2.ListLikedYouRequest{RecipientUserId: 1})
+				return err
+			},
+			"CountLikedYou": func() error {
+				_, err := explorerService.CountLikedYou(testCase.ctx, &explorev2.CountLikedYouRequest{RecipientUserId: 1})
+				return err
+			},
+			"PutDecision": func() error {
+				_, err := explorerService.PutDecision(testCase.ctx, &explorev2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 2})
+				return err
+			},
+			"ListMatches": func() error {
+				_, err := explorerService.ListMatches(testCase.ctx, &explorev2.ListMatchesRequest{UserId: 1})
+				return err
+			},
+		}
+
+		for name, call := range calls {
+			if code := status.Code(call()); code != testCase.expectedCode {
+				t.Fatalf("%s - %s: expected %s, got %s", testCase.name, name, testCase.expectedCode, code)
+			}
+		}
+
+		// Only the rejected watchers are checked here, the allowed ones are covered by Test_WatchLikes
+		if testCase.expectedCode != codes.OK {
+			err := explorerService.WatchLikes(&explorev2.WatchLikesRequest{RecipientUserId: 1}, &likeEventsStream{ctx: testCase.ctx})
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+		}
+	}
+}
+
+func Test_BlockAndUnblockUser(t *testing.T) {
+	repositoryMock := activeUsersRepositoryMock()
+
+	repositoryMock.On("BlockUser", mock.Anything, entity.UserID(1), entity.UserID(2)).Once().Return(nil)
+	repositoryMock.On("UnblockUser", mock.Anything, entity.UserID(1), entity.UserID(2)).Once().Return(nil)
+	repositoryMock.On("BlockUser", mock.Anything, entity.UserID(1), entity.UserID(9)).Once().Return(domainError.NewUserNotFoundErr())
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	_, err := explorerService.BlockUser(asUser(1), &explorev2.BlockUserRequest{ActorUserId: 1, BlockedUserId: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = explorerService.UnblockUser(asUser(1), &explorev2.UnblockUserRequest{ActorUserId: 1, BlockedUserId: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = explorerService.BlockUser(asUser(1), &explorev2.BlockUserRequest{ActorUserId: 1, BlockedUserId: 9})
+	assert.Equal(t, status.Code(err), codes.NotFound)
+
+	// Nobody can block on behalf of another user
+	_, err = explorerService.BlockUser(asUser(2), &explorev2.BlockUserRequest{ActorUserId: 1, BlockedUserId: 2})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	_, err = explorerService.UnblockUser(asUser(2), &explorev2.UnblockUserRequest{ActorUserId: 1, BlockedUserId: 2})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_ReportUser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *explorev2.ReportUserRequest
+		expectedReason entity.ReportReason
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "Report with details",
+			request:        &explorev2.ReportUserRequest{ActorUserId: 1, ReportedUserId: 2, Reason: explorev2.ReportUserRequest_REASON_HARASSMENT, Details: "rude messages"},
+			expectedReason: entity.ReportReasonHarassment,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Report without details",
+			request:        &explorev2.ReportUserRequest{ActorUserId: 1, ReportedUserId: 2, Reason: explorev2.ReportUserRequest_REASON_FAKE_PROFILE},
+			expectedReason: entity.ReportReasonFakeProfile,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:         "Missing reason",
+			request:      &explorev2.ReportUserRequest{ActorUserId: 1, ReportedUserId: 2},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := activeUsersRepositoryMock()
+
+			if testCase.expectedCode == codes.OK {
+				repositoryMock.On("CreateReport", mock.Anything, &entity.Report{
+					ReporterID: 1,
+					ReportedID: 2,
+					Reason:     testCase.expectedReason,
+					Details:    testCase.request.Details,
+				}).Once().Return(nil)
+			}
+
+			explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+			_, err := explorerService.ReportUser(asUser(1), testCase.request)
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_UndoLastDecision(t *testing.T) {
+	undoneAt := time.Now()
+	pass := entity.DecisionTypePass
+
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		change            *entity.DecisionChange
+		matchDissolved    bool
+		undoErr           error
+		expectedResponse  *explorev2.UndoLastDecisionResponse
+		expectedPublished []event.LikeEvent
+		expectedCode      codes.Code
+	}{
+		{
+			name:           "Undo a like that made a match, both users are notified",
+			ctx:            asUser(1),
+			change:         &entity.DecisionChange{AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeLike, UndoneAt: &undoneAt},
+			matchDissolved: true,
+			expectedResponse: &explorev2.UndoLastDecisionResponse{
+				RecipientUserId:    2,
+				UndoneDecisionType: explorev2.DecisionType_DECISION_TYPE_LIKE,
+				MatchDissolved:     true,
+			},
+			expectedPublished: []event.LikeEvent{
+				{Type: event.LikeEventTypeUnmatch, ActorID: 1, RecipientID: 2, OccurredAt: undoneAt},
+				{Type: event.LikeEventTypeUnmatch, ActorID: 2, RecipientID: 1, OccurredAt: undoneAt},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:   "Undo a like that replaced a pass",
+			ctx:    asUser(1),
+			change: &entity.DecisionChange{AuthorID: 1, RecipientID: 2, Type: entity.DecisionTypeLike, PreviousType: &pass, UndoneAt: &undoneAt},
+			expectedResponse: &explorev2.UndoLastDecisionResponse{
+				RecipientUserId:      2,
+				UndoneDecisionType:   explorev2.DecisionType_DECISION_TYPE_LIKE,
+				RestoredDecisionType: explorev2.DecisionType_DECISION_TYPE_PASS,
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Last decision out of the window",
+			ctx:          asUser(1),
+			undoErr:      domainError.NewUndoWindowExpiredErr(),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Nothing to undo",
+			ctx:          asUser(1),
+			undoErr:      domainError.NewDecisionNotFoundErr(),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Undo the decision of another user",
+			ctx:          asUser(2),
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := activeUsersRepositoryMock()
+			likeHubMock := &event_mock.MockLikeHub{}
+
+			// The changes made since the start of the window can be undone
+			inWindow := mock.MatchedBy(func(since time.Time) bool {
+				return time.Since(since) >= time.Minute && time.Since(since) < time.Minute+time.Second
+			})
+
+			if testCase.expectedCode != codes.PermissionDenied {
+				repositoryMock.
+					On("UndoLastDecision", mock.Anything, entity.UserID(1), inWindow).
+					Once().Return(testCase.change, testCase.matchDissolved, testCase.undoErr)
+			}
+
+			for _, published := range testCase.expectedPublished {
+				likeHubMock.On("Publish", mock.Anything, published).Once().Return()
+			}
+
+			explorerService := NewExplorerServer(repositoryMock, likeHubMock, &event_mock.MockDecisionRecorder{})
+			explorerService.SetUndoWindow(time.Minute)
+
+			response, err := explorerService.UndoLastDecision(testCase.ctx, &explorev2.UndoLastDecisionRequest{ActorUserId: 1})
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+
+			if testCase.expectedResponse != nil {
+				assert.Equal(t, response.RecipientUserId, testCase.expectedResponse.RecipientUserId)
+				assert.Equal(t, response.UndoneDecisionType, testCase.expectedResponse.UndoneDecisionType)
+				assert.Equal(t, response.RestoredDecisionType, testCase.expectedResponse.RestoredDecisionType)
+				assert.Equal(t, response.MatchDissolved, testCase.expectedResponse.MatchDissolved)
+			}
+
+			repositoryMock.AssertExpectations(t)
+			likeHubMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ListDecisionHistory(t *testing.T) {
+	nowTime := time.Now()
+	like := entity.DecisionTypeLike
+	pass := entity.DecisionTypePass
+
+	asAdmin := auth.NewContext(context.Background(), &auth.Principal{
+		Subject: "support",
+		Roles:   []auth.Role{auth.RoleAdmin},
+	})
+
+	// One event more than the page size means there is a next page
+	dbEvents := make([]entity.DecisionEvent, 0, DecisionHistoryPageSize+1)
+	for i := 0; i < DecisionHistoryPageSize+1; i++ {
+		dbEvents = append(dbEvents, entity.DecisionEvent{
+			ID:          int64(DecisionHistoryPageSize + 1 - i),
+			AuthorID:    3,
+			RecipientID: 1,
+			OldType:     &like,
+			NewType:     &pass,
+			Cause:       entity.DecisionEventCausePut,
+			RequestID:   "gateway-42",
+			OccurredAt:  nowTime,
+		})
+	}
+
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	query := repository.DecisionEventsQuery{UserID: 3, OtherUserID: 1}
+
+	repositoryMock.
+		On("ListDecisionEvents", mock.Anything, query, (*repository.DecisionEventCursor)(nil), DecisionHistoryPageSize+1).
+		Once().Return(dbEvents, nil)
+
+	repositoryMock.
+		On("ListDecisionEvents", mock.Anything, query, &repository.DecisionEventCursor{ID: 2}, DecisionHistoryPageSize+1).
+		Once().Return([]entity.DecisionEvent{}, nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	otherUserID := int64(1)
+	request := &explorev2.ListDecisionHistoryRequest{UserId: 3, OtherUserId: &otherUserID}
+
+	response, err := explorerService.ListDecisionHistory(asAdmin, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Events), DecisionHistoryPageSize)
+	assert.Equal(t, response.Events[0].AuthorUserId, int64(3))
+	assert.Equal(t, response.Events[0].RecipientUserId, int64(1))
+	assert.Equal(t, response.Events[0].OldDecisionType, explorev2.DecisionType_DECISION_TYPE_LIKE)
+	assert.Equal(t, response.Events[0].NewDecisionType, explorev2.DecisionType_DECISION_TYPE_PASS)
+	assert.Equal(t, response.Events[0].Cause, explorev2.ListDecisionHistoryResponse_Event_CAUSE_PUT)
+	assert.Equal(t, response.Events[0].RequestId, "gateway-42")
+	assert.Equal(t, response.Events[0].OccurredAtUnixTimestamp, uint64(nowTime.Unix()))
+
+	if response.NextPaginationToken == nil {
+		t.Fatal("expected a next pagination token")
+	}
+
+	// The token points right after the last event of the first page
+	request.PaginationToken = response.NextPaginationToken
+
+	response, err = explorerService.ListDecisionHistory(asAdmin, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(response.Events), 0)
+	assert.Equal(t, response.NextPaginationToken, (*string)(nil))
+
+	// Only the admins can read the history, even the users can't read their own
+	_, err = explorerService.ListDecisionHistory(asUser(3), &explorev2.ListDecisionHistoryRequest{UserId: 3})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	_, err = explorerService.ListDecisionHistory(asService(), &explorev2.ListDecisionHistoryRequest{UserId: 3})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_BuildDummyDataset(t *testing.T) {
+	ctx := context.Background()
+
+	// The store had users before, the decisions are made between the new ones
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+	repositoryMock.On("GetUser", mock.Anything, entity.UserID(1)).Once().Return(nil, domainError.NewUserNotFoundErr())
+
+	nextUserID := entity.UserID(10)
+	repositoryMock.
+		On("CreateUser", mock.Anything, mock.AnythingOfType("*entity.User")).
+		Times(4).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.User).ID = nextUserID
+			nextUserID++
+		}).
+		Return(nil)
+
+	created := make([]entity.Decision, 0)
+	repositoryMock.
+		On("CreateDecision", mock.Anything, mock.AnythingOfType("*entity.Decision")).
+		Times(3).
+		Run(func(args mock.Arguments) {
+			created = append(created, *args.Get(1).(*entity.Decision))
+		}).
+		Return(nil)
+
+	explorerService := NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	if err := explorerService.BuildDummyDataset(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, created, []entity.Decision{
+		{AuthorID: 10, RecipientID: 11, Type: entity.DecisionTypeLike},
+		{AuthorID: 11, RecipientID: 10, Type: entity.DecisionTypeLike},
+		{AuthorID: 13, RecipientID: 10, Type: entity.DecisionTypeLike},
+	})
+
+	repositoryMock.AssertExpectations(t)
+
+	// Once seeded, the dataset isn't inserted again
+	seededRepositoryMock := activeUsersRepositoryMock()
+
+	explorerService = NewExplorerServer(seededRepositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	if err := explorerService.BuildDummyDataset(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	seededRepositoryMock.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+
+	// A failure is reported instead of leaving a partial dataset unnoticed
+	failingRepositoryMock := &repository_mock.MockExplorerRepository{}
+	failingRepositoryMock.On("GetUser", mock.Anything, entity.UserID(1)).Once().Return(nil, domainError.NewUserNotFoundErr())
+	failingRepositoryMock.On("CreateUser", mock.Anything, mock.Anything).Once().Return(errors.New("connection refused"))
+
+	explorerService = NewExplorerServer(failingRepositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{})
+
+	err := explorerService.BuildDummyDataset(ctx)
+	assert.Equal(t, err.Error(), "error creating dummy user: connection refused")
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	"google.golang.org/grpc"
+)
+
+// Serves the v1 API, where the user IDs are numeric strings, on top of the v2 server.
+// The IDs are parsed and every call is delegated so both versions behave the same way.
+type ExploreServerV1 struct {
+	ep.UnimplementedExploreServiceServer
+	server *ExploreServer
+}
+
+func NewExplorerServerV1(server *ExploreServer) *ExploreServerV1 {
+	return &ExploreServerV1{
+		server: server,
+	}
+}
+
+func (s *ExploreServerV1) ListLikedYou(ctx context.Context, request *ep.ListLikedYouRequest) (*ep.ListLikedYouResponse, error) {
+	recipientUserID, err := parseUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response, err := s.server.ListLikedYou(ctx, &epv2.ListLikedYouRequest{
+		RecipientUserId: recipientUserID.Int64(),
+		PaginationToken: request.PaginationToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toListLikedYouResponseV1(response), nil
+}
+
+func (s *ExploreServerV1) ListNewLikedYou(ctx context.Context, request *ep.ListLikedYouRequest) (*ep.ListLikedYouResponse, error) {
+	recipientUserID, err := parseUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response, err := s.server.ListNewLikedYou(ctx, &epv2.ListLikedYouRequest{
+		RecipientUserId: recipientUserID.Int64(),
+		PaginationToken: request.PaginationToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toListLikedYouResponseV1(response), nil
+}
+
+func toListLikedYouResponseV1(response *epv2.ListLikedYouResponse) *ep.ListLikedYouResponse {
+	likers := make([]*ep.ListLikedYouResponse_Liker, 0, len(response.GetLikers()))
+
+	for _, liker := range response.GetLikers() {
+		likers = append(likers, &ep.ListLikedYouResponse_Liker{
+			ActorId:       formatUserID(liker.GetActorId()),
+			UnixTimestamp: liker.GetUnixTimestamp(),
+		})
+	}
+
+	return &ep.ListLikedYouResponse{
+		Likers:              likers,
+		NextPaginationToken: response.NextPaginationToken,
+	}
+}
+
+func (s *ExploreServerV1) CountLikedYou(ctx context.Context, request *ep.CountLikedYouRequest) (*ep.CountLikedYouResponse, error) {
+	recipientUserID, err := parseUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response, err := s.server.CountLikedYou(ctx, &epv2.CountLikedYouRequest{
+		RecipientUserId: recipientUserID.Int64(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ep.CountLikedYouResponse{
+		Count: response.GetCount(),
+	}, nil
+}
+
+func (s *ExploreServerV1) PutDecision(ctx context.Context, request *ep.PutDecisionRequest) (*ep.PutDecisionResponse, error) {
+	actorUserID, err := parseUserID("actor_user_id", request.GetActorUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	recipientUserID, err := parseUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// v1 only knows likes and passes
+	decisionType := epv2.DecisionType_DECISION_TYPE_PASS
+	if request.GetLikedRecipient() {
+		decisionType = epv2.DecisionType_DECISION_TYPE_LIKE
+	}
+
+	response, err := s.server.PutDecision(ctx, &epv2.PutDecisionRequest{
+		ActorUserId:     actorUserID.Int64(),
+		RecipientUserId: recipientUserID.Int64(),
+		DecisionType:    decisionType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ep.PutDecisionResponse{
+		MutualLikes: response.GetMutualLikes(),
+	}, nil
+}
+
+func (s *ExploreServerV1) ListMatches(ctx context.Context, request *ep.ListMatchesRequest) (*ep.ListMatchesResponse, error) {
+	userID, err := parseUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response, err := s.server.ListMatches(ctx, &epv2.ListMatchesRequest{
+		UserId:          userID.Int64(),
+		PaginationToken: request.PaginationToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*ep.ListMatchesResponse_Match, 0, len(response.GetMatches()))
+	for _, match := range response.GetMatches() {
+		matches = append(matches, &ep.ListMatchesResponse_Match{
+			UserId:                 formatUserID(match.GetUserId()),
+			MatchedAtUnixTimestamp: match.GetMatchedAtUnixTimestamp(),
+		})
+	}
+
+	return &ep.ListMatchesResponse{
+		Matches:             matches,
+		NextPaginationToken: response.NextPaginationToken,
+	}, nil
+}
+
+func (s *ExploreServerV1) WatchLikes(request *ep.WatchLikesRequest, stream ep.ExploreService_WatchLikesServer) error {
+	recipientUserID, err := parseUserID("recipient_user_id", request.GetRecipientUserId())
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	return s.server.WatchLikes(&epv2.WatchLikesRequest{
+		RecipientUserId: recipientUserID.Int64(),
+		ResumeToken:     request.ResumeToken,
+	}, &likeEventStreamV1{ServerStream: stream, stream: stream})
+}
+
+// Sends the v2 like events of the v2 server to a v1 stream
+type likeEventStreamV1 struct {
+	grpc.ServerStream
+	stream ep.ExploreService_WatchLikesServer
+}
+
+func (s *likeEventStreamV1) Send(likeEvent *epv2.LikeEvent) error {
+	// v1 only knows the likes and the matches, a super like is a like and an unmatch isn't sent
+	eventType := ep.LikeEvent_Type(likeEvent.GetType()) // Same values in both versions
+	switch likeEvent.GetType() {
+	case epv2.LikeEvent_TYPE_SUPER_LIKE:
+		eventType = ep.LikeEvent_TYPE_LIKE
+	case epv2.LikeEvent_TYPE_UNMATCH:
+		return nil
+	}
+
+	return s.stream.Send(&ep.LikeEvent{
+		Type:          eventType,
+		ActorId:       formatUserID(likeEvent.GetActorId()),
+		UnixTimestamp: likeEvent.GetUnixTimestamp(),
+		ResumeToken:   likeEvent.GetResumeToken(),
+	})
+}
+
+func formatUserID(userID int64) string {
+	return strconv.FormatInt(userID, 10)
+}
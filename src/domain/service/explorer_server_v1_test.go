@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	event_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/event"
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_V1_ConvertsTheUserIds(t *testing.T) {
+	nowTime := time.Now()
+
+	repositoryMock := activeUsersRepositoryMock()
+
+	repositoryMock.
+		On("ListLikersForRecipientId", mock.Anything, entity.UserID(1), repository.LikersQuery{}, (*repository.DecisionCursor)(nil), LikersPageSize+1).
+		Once().Return([]entity.Decision{{ID: 1, AuthorID: 2, RecipientID: 1, Type: entity.DecisionTypeLike, UpdatedAt: nowTime}}, nil)
+
+	repositoryMock.
+		On("ListMatchesForUserId", mock.Anything, entity.UserID(1), (*repository.MatchCursor)(nil), MatchesPageSize+1).
+		Once().Return([]entity.Match{{UserID: 9007199254740993, MatchedAt: nowTime}}, nil)
+
+	repositoryMock.
+		On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).
+		Once().Return(map[entity.DecisionType]int64{entity.DecisionTypeLike: 2, entity.DecisionTypeSuperLike: 1}, nil)
+
+	explorerService := NewExplorerServerV1(NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{}))
+
+	likes, err := explorerService.ListLikedYou(asUser(1), &explore.ListLikedYouRequest{RecipientUserId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(likes.Likers), 1)
+	assert.Equal(t, likes.Likers[0].ActorId, "2")
+	assert.Equal(t, likes.Likers[0].UnixTimestamp, uint64(nowTime.Unix()))
+
+	// The BIGINT IDs are formatted as they are, even above the precision of a float64
+	matches, err := explorerService.ListMatches(asUser(1), &explore.ListMatchesRequest{UserId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches.Matches), 1)
+	assert.Equal(t, matches.Matches[0].UserId, "9007199254740993")
+
+	count, err := explorerService.CountLikedYou(asUser(1), &explore.CountLikedYouRequest{RecipientUserId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, count.Count, uint64(3))
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_V1_InvalidUserIdsReturnInvalidArgument(t *testing.T) {
+	invalidUserIds := []string{"abc", "", "0", "-1", "1.5", "9223372036854775808"}
+
+	repositoryMock := activeUsersRepositoryMock()
+
+	explorerService := NewExplorerServerV1(NewExplorerServer(repositoryMock, &event_mock.MockLikeHub{}, &event_mock.MockDecisionRecorder{}))
+	ctx := asUser(1)
+
+	for _, userId := range invalidUserIds {
+		calls := map[string]func() error{
+			"recipient_user_id": func() error {
+				_, err := explorerService.ListLikedYou(ctx, &explore.ListLikedYouRequest{RecipientUserId: userId})
+				return err
+			},
+			"actor_user_id": func() error {
+				_, err := explorerService.PutDecision(ctx, &explore.PutDecisionRequest{ActorUserId: userId, RecipientUserId: "2"})
+				return err
+			},
+			"user_id": func() error {
+				_, err := explorerService.ListMatches(ctx, &explore.ListMatchesRequest{UserId: userId})
+				return err
+			},
+		}
+
+		for field, call := range calls {
+			st, ok := status.FromError(call())
+			if !ok {
+				t.Fatalf("%q: expected a gRPC status error", userId)
+			}
+
+			assert.Equal(t, st.Code(), codes.InvalidArgument)
+
+			if len(st.Details()) != 1 {
+				t.Fatalf("%q: expected the bad request details", userId)
+			}
+
+			badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+			if !ok {
+				t.Fatalf("%q: expected the bad request details", userId)
+			}
+
+			assert.Equal(t, badRequest.FieldViolations[0].Field, field)
+		}
+	}
+
+	repositoryMock.AssertExpectations(t)
+}
+
+// v1 server stream that records the events sent to the client
+type likeEventsStreamV1 struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*explore.LikeEvent
+}
+
+func (s *likeEventsStreamV1) Context() context.Context {
+	return s.ctx
+}
+
+func (s *likeEventsStreamV1) Send(likeEvent *explore.LikeEvent) error {
+	s.sent = append(s.sent, likeEvent)
+	return nil
+}
+
+func Test_V1_WatchLikes(t *testing.T) {
+	nowTime := time.Now()
+
+	events := make(chan event.LikeEvent, 4)
+	events <- event.LikeEvent{Type: event.LikeEventTypeLike, ActorID: 2, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.1"}
+	events <- event.LikeEvent{Type: event.LikeEventTypeMatch, ActorID: 3, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.2"}
+	events <- event.LikeEvent{Type: event.LikeEventTypeUnmatch, ActorID: 3, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.3"}
+	events <- event.LikeEvent{Type: event.LikeEventTypeSuperLike, ActorID: 4, RecipientID: 1, OccurredAt: nowTime, Cursor: "a.4"}
+	close(events)
+
+	likeHubMock := &event_mock.MockLikeHub{}
+	subscriptionMock := &event_mock.MockLikeSubscription{}
+
+	likeHubMock.On("Subscribe", mock.Anything, entity.UserID(1), "a.0").Once().Return(subscriptionMock, nil)
+	subscriptionMock.On("Events").Return((<-chan event.LikeEvent)(events))
+	subscriptionMock.On("Err").Once().Return(nil)
+	subscriptionMock.On("Close").Once().Return()
+
+	explorerService := NewExplorerServerV1(NewExplorerServer(activeUsersRepositoryMock(), likeHubMock, &event_mock.MockDecisionRecorder{}))
+
+	resumeToken := "a.0"
+	stream := &likeEventsStreamV1{ctx: asUser(1)}
+
+	err := explorerService.WatchLikes(&explore.WatchLikesRequest{RecipientUserId: "1", ResumeToken: &resumeToken}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, stream.sent, []*explore.LikeEvent{
+		{Type: explore.LikeEvent_TYPE_LIKE, ActorId: "2", UnixTimestamp: uint64(nowTime.Unix()), ResumeToken: "a.1"},
+		{Type: explore.LikeEvent_TYPE_MATCH, ActorId: "3", UnixTimestamp: uint64(nowTime.Unix()), ResumeToken: "a.2"},
+		// v1 doesn't know the unmatches, and a super like is a like
+		{Type: explore.LikeEvent_TYPE_LIKE, ActorId: "4", UnixTimestamp: uint64(nowTime.Unix()), ResumeToken: "a.4"},
+	})
+
+	likeHubMock.AssertExpectations(t)
+	subscriptionMock.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+// Maximum number of likers returned in a single page. The size is enforced by the server
+// so clients can't pull a full liker list in one go.
+const LikersPageSize = 50
+
+// Maximum number of matches returned in a single page
+const MatchesPageSize = 50
+
+// Number of decisions read at once while exporting the data of a user
+const ExportPageSize = 500
+
+// Maximum number of decision events returned in a single page
+const DecisionHistoryPageSize = 100
+
+// Content of the opaque pagination token handed to the clients.
+// Every list is ordered by a timestamp and an ID, which is all we need to find the next page,
+// except the decision history which is ordered by ID only.
+// The liker lists ranked by type also need the rank of the last liker.
+type paginationToken struct {
+	Rank int   `json:"r,omitempty"` // Rank of the decision type of the last liker in the page
+	At   int64 `json:"u"`           // Unix nanoseconds of the last item in the page
+	ID   int64 `json:"i"`           // ID of the last item in the page
+}
+
+// Encodes the position of the last item of a page into an opaque pagination token
+func encodePaginationToken(position paginationToken) string {
+	payload, _ := json.Marshal(position)
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// Decodes a pagination token, a nil token means the first page
+func decodePaginationToken(token *string) (*paginationToken, error) {
+	if token == nil || *token == "" {
+		return nil, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(*token)
+	if err != nil {
+		return nil, domainError.NewInvalidArgumentErr("pagination_token", "is not a valid pagination token")
+	}
+
+	var decoded paginationToken
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, domainError.NewInvalidArgumentErr("pagination_token", "is not a valid pagination token")
+	}
+
+	return &decoded, nil
+}
+
+// Decodes a pagination token of a liker list into a repository cursor
+func decodeDecisionCursor(token *string) (*repository.DecisionCursor, error) {
+	decoded, err := decodePaginationToken(token)
+	if err != nil || decoded == nil {
+		return nil, err
+	}
+
+	return &repository.DecisionCursor{
+		Rank:      decoded.Rank,
+		UpdatedAt: time.Unix(0, decoded.At),
+		ID:        decoded.ID,
+	}, nil
+}
+
+// Decodes a pagination token of a match list into a repository cursor
+func decodeMatchCursor(token *string) (*repository.MatchCursor, error) {
+	decoded, err := decodePaginationToken(token)
+	if err != nil || decoded == nil {
+		return nil, err
+	}
+
+	return &repository.MatchCursor{
+		MatchedAt: time.Unix(0, decoded.At),
+		UserID:    entity.UserID(decoded.ID),
+	}, nil
+}
+
+// Decodes a pagination token of the decision history into a repository cursor
+func decodeDecisionEventCursor(token *string) (*repository.DecisionEventCursor, error) {
+	decoded, err := decodePaginationToken(token)
+	if err != nil || decoded == nil {
+		return nil, err
+	}
+
+	return &repository.DecisionEventCursor{
+		ID: decoded.ID,
+	}, nil
+}
+
+// Trims the extra item fetched to detect a next page and returns the token pointing to it.
+// The position function returns what the list is ordered by.
+func pageOf[T any](items []T, pageSize int, position func(T) paginationToken) ([]T, *string) {
+	if len(items) <= pageSize {
+		return items, nil
+	}
+
+	items = items[:pageSize]
+	nextToken := encodePaginationToken(position(items[pageSize-1]))
+
+	return items, &nextToken
+}
+
+func decisionPosition(decision entity.Decision) paginationToken {
+	return paginationToken{
+		Rank: decision.Type.Rank(),
+		At:   decision.UpdatedAt.UnixNano(),
+		ID:   decision.ID,
+	}
+}
+
+func matchPosition(match entity.Match) paginationToken {
+	return paginationToken{
+		At: match.MatchedAt.UnixNano(),
+		ID: match.UserID.Int64(),
+	}
+}
+
+// The decision events are ordered by their ID only
+func decisionEventPosition(decisionEvent entity.DecisionEvent) paginationToken {
+	return paginationToken{
+		ID: decisionEvent.ID,
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
+	upv1 "github.com/lokker96/grpc_project/infrastructure/proto/user/v1"
+)
+
+// Serves the lifecycle of the user accounts. The explore server checks the users against the same table,
+// so a deactivated or deleted user stops being listed right away.
+type UserServer struct {
+	upv1.UnimplementedUserServiceServer
+	explorerRepository repository.ExplorerRepository
+	likeForgetter      event.LikeForgetter // Drops what the like hub and the projections keep in memory about the erased users
+}
+
+func NewUserServer(explorerRepository repository.ExplorerRepository, likeForgetter event.LikeForgetter) *UserServer {
+	return &UserServer{
+		explorerRepository: explorerRepository,
+		likeForgetter:      likeForgetter,
+	}
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, request *upv1.CreateUserRequest) (*upv1.CreateUserResponse, error) {
+	// The accounts are created by the sign up backend, not by the users themselves
+	if err := authorizeService(ctx); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	user := &entity.User{}
+	if err := s.explorerRepository.CreateUser(ctx, user); err != nil {
+		return nil, toStatusError(fmt.Errorf("error creating user: %w", err))
+	}
+
+	return &upv1.CreateUserResponse{
+		User: toUser(user),
+	}, nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, request *upv1.GetUserRequest) (*upv1.GetUserResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	user, err := s.explorerRepository.GetUser(ctx, userID)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error getting user: %w", err))
+	}
+
+	return &upv1.GetUserResponse{
+		User: toUser(user),
+	}, nil
+}
+
+func (s *UserServer) DeactivateUser(ctx context.Context, request *upv1.DeactivateUserRequest) (*upv1.DeactivateUserResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	user, err := s.explorerRepository.DeactivateUser(ctx, userID)
+	if err != nil {
+		return nil, toStatusError(fmt.Errorf("error deactivating user: %w", err))
+	}
+
+	return &upv1.DeactivateUserResponse{
+		User: toUser(user),
+	}, nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, request *upv1.DeleteUserRequest) (*upv1.DeleteUserResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := s.explorerRepository.DeleteUser(ctx, userID); err != nil {
+		return nil, toStatusError(fmt.Errorf("error deleting user: %w", err))
+	}
+
+	return &upv1.DeleteUserResponse{}, nil
+}
+
+// Streams every decision authored or received by the user, most recently updated first.
+// The decisions are read a page at a time so the export of a busy user doesn't sit in memory.
+func (s *UserServer) ExportUserData(request *upv1.ExportUserDataRequest, stream upv1.UserService_ExportUserDataServer) error {
+	ctx := stream.Context()
+
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return toStatusError(err)
+	}
+
+	var cursor *repository.DecisionCursor
+
+	for {
+		decisions, err := s.explorerRepository.ListDecisionsForUserId(ctx, userID, cursor, ExportPageSize)
+		if err != nil {
+			return toStatusError(fmt.Errorf("error listing decisions: %w", err))
+		}
+
+		for _, decision := range decisions {
+			decisionJSON, err := json.Marshal(toExportedDecision(decision))
+			if err != nil {
+				return toStatusError(fmt.Errorf("error encoding decision: %w", err))
+			}
+
+			if err := stream.Send(&upv1.ExportUserDataResponse{DecisionJson: string(decisionJSON)}); err != nil {
+				return err
+			}
+		}
+
+		if len(decisions) < ExportPageSize {
+			return nil
+		}
+
+		last := decisions[len(decisions)-1]
+		cursor = &repository.DecisionCursor{
+			UpdatedAt: last.UpdatedAt,
+			ID:        last.ID,
+		}
+	}
+}
+
+// Erases the user and every decision it authored or received. Only an audit record without personal data is kept.
+// The like hub and the projections of the decision log are purged, the other replicas hide the erased user when reading.
+func (s *UserServer) EraseUser(ctx context.Context, request *upv1.EraseUserRequest) (*upv1.EraseUserResponse, error) {
+	userID, err := validateUserID("user_id", request.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err := authorize(ctx, userID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	erasure := &entity.UserErasure{
+		RequestedBy: requestedBy(ctx),
+	}
+
+	if err := s.explorerRepository.EraseUser(ctx, userID, erasure); err != nil {
+		return nil, toStatusError(fmt.Errorf("error erasing user: %w", err))
+	}
+
+	s.likeForgetter.Forget(userID)
+
+	return &upv1.EraseUserResponse{
+		DecisionsErased: uint64(erasure.DecisionsErased),
+	}, nil
+}
+
+// Decision as it is exported to the user
+type exportedDecision struct {
+	ID          int64     `json:"id"`
+	AuthorID    int64     `json:"author_id"`
+	RecipientID int64     `json:"recipient_id"`
+	Type        string    `json:"type"` // pass, like or super_like
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func toExportedDecision(decision entity.Decision) exportedDecision {
+	return exportedDecision{
+		ID:          decision.ID,
+		AuthorID:    decision.AuthorID.Int64(),
+		RecipientID: decision.RecipientID.Int64(),
+		Type:        string(decision.Type),
+		CreatedAt:   decision.CreatedAt.UTC(),
+		UpdatedAt:   decision.UpdatedAt.UTC(),
+	}
+}
+
+// Role of the caller recorded in the erasure audit, the subject is left out on purpose
+func requestedBy(ctx context.Context) string {
+	principal, ok := auth.FromContext(ctx)
+
+	switch {
+	case ok && principal.HasRole(auth.RoleAdmin):
+		return string(auth.RoleAdmin)
+	case ok && principal.HasRole(auth.RoleService):
+		return string(auth.RoleService)
+	default:
+		return "user"
+	}
+}
+
+func toUser(user *entity.User) *upv1.User {
+	response := &upv1.User{
+		Id:                     user.ID.Int64(),
+		State:                  upv1.User_STATE_ACTIVE,
+		CreatedAtUnixTimestamp: uint64(user.CreatedAt.Unix()),
+	}
+
+	if user.IsDeactivated() {
+		deactivatedAt := uint64(user.DeactivatedAt.Unix())
+
+		response.State = upv1.User_STATE_DEACTIVATED
+		response.DeactivatedAtUnixTimestamp = &deactivatedAt
+	}
+
+	return response
+}
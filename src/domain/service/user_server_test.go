@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+	upv1 "github.com/lokker96/grpc_project/infrastructure/proto/user/v1"
+	event_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/event"
+	repository_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/repository"
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func asService() context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{
+		Subject: "signup",
+		Roles:   []auth.Role{auth.RoleService},
+	})
+}
+
+func Test_CreateUser(t *testing.T) {
+	nowTime := time.Now()
+
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	repositoryMock.
+		On("CreateUser", mock.Anything, mock.AnythingOfType("*entity.User")).
+		Once().
+		Run(func(args mock.Arguments) {
+			user := args.Get(1).(*entity.User)
+			user.ID = 5
+			user.CreatedAt = nowTime
+		}).
+		Return(nil)
+
+	userService := NewUserServer(repositoryMock, &event_mock.MockLikeHub{})
+
+	response, err := userService.CreateUser(asService(), &upv1.CreateUserRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, response.User.Id, int64(5))
+	assert.Equal(t, response.User.State, upv1.User_STATE_ACTIVE)
+	assert.Equal(t, response.User.CreatedAtUnixTimestamp, uint64(nowTime.Unix()))
+
+	// The users can't create accounts themselves
+	_, err = userService.CreateUser(asUser(1), &upv1.CreateUserRequest{})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_GetUser(t *testing.T) {
+	deactivatedAt := time.Now()
+
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		user          *entity.User
+		userErr       error
+		expectedCode  codes.Code
+		expectedState upv1.User_State
+	}{
+		{
+			name:          "Active user",
+			ctx:           asUser(1),
+			user:          &entity.User{ID: 1},
+			expectedCode:  codes.OK,
+			expectedState: upv1.User_STATE_ACTIVE,
+		},
+		{
+			name:          "Deactivated user",
+			ctx:           asUser(1),
+			user:          &entity.User{ID: 1, DeactivatedAt: &deactivatedAt},
+			expectedCode:  codes.OK,
+			expectedState: upv1.User_STATE_DEACTIVATED,
+		},
+		{
+			name:         "Unknown or deleted user",
+			ctx:          asService(),
+			userErr:      domainError.NewUserNotFoundErr(),
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := &repository_mock.MockExplorerRepository{}
+
+			repositoryMock.
+				On("GetUser", mock.Anything, entity.UserID(1)).
+				Once().Return(testCase.user, testCase.userErr)
+
+			response, err := NewUserServer(repositoryMock, &event_mock.MockLikeHub{}).GetUser(testCase.ctx, &upv1.GetUserRequest{UserId: 1})
+
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+			assert.Equal(t, response.GetUser().GetState(), testCase.expectedState)
+
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeactivateUser(t *testing.T) {
+	deactivatedAt := time.Now()
+
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	repositoryMock.
+		On("DeactivateUser", mock.Anything, entity.UserID(1)).
+		Once().Return(&entity.User{ID: 1, DeactivatedAt: &deactivatedAt}, nil)
+
+	userService := NewUserServer(repositoryMock, &event_mock.MockLikeHub{})
+
+	response, err := userService.DeactivateUser(asUser(1), &upv1.DeactivateUserRequest{UserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, response.User.State, upv1.User_STATE_DEACTIVATED)
+	assert.Equal(t, response.User.GetDeactivatedAtUnixTimestamp(), uint64(deactivatedAt.Unix()))
+
+	// The other users can't deactivate the account
+	_, err = userService.DeactivateUser(asUser(2), &upv1.DeactivateUserRequest{UserId: 1})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_DeleteUser(t *testing.T) {
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	repositoryMock.On("DeleteUser", mock.Anything, entity.UserID(1)).Once().Return(nil)
+	repositoryMock.On("DeleteUser", mock.Anything, entity.UserID(1)).Once().Return(domainError.NewUserNotFoundErr())
+
+	userService := NewUserServer(repositoryMock, &event_mock.MockLikeHub{})
+
+	_, err := userService.DeleteUser(asUser(1), &upv1.DeleteUserRequest{UserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted users are not found anymore
+	_, err = userService.DeleteUser(asUser(1), &upv1.DeleteUserRequest{UserId: 1})
+	assert.Equal(t, status.Code(err), codes.NotFound)
+
+	_, err = userService.DeleteUser(asUser(1), &upv1.DeleteUserRequest{UserId: 0})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+type exportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*upv1.ExportUserDataResponse
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(response *upv1.ExportUserDataResponse) error {
+	s.sent = append(s.sent, response)
+	return nil
+}
+
+func Test_ExportUserData(t *testing.T) {
+	nowTime := time.Now()
+
+	// A full page followed by a last one, the second page starts after the last decision of the first
+	firstPage := make([]entity.Decision, ExportPageSize)
+	for i := range firstPage {
+		firstPage[i] = entity.Decision{ID: int64(i + 1), AuthorID: 1, RecipientID: entity.UserID(i + 2), Type: entity.DecisionTypeLike, UpdatedAt: nowTime}
+	}
+
+	lastPage := []entity.Decision{
+		{ID: 1000, AuthorID: 3, RecipientID: 1, Type: entity.DecisionTypePass, UpdatedAt: nowTime},
+	}
+
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+
+	repositoryMock.
+		On("ListDecisionsForUserId", mock.Anything, entity.UserID(1), (*repository.DecisionCursor)(nil), ExportPageSize).
+		Once().Return(firstPage, nil)
+	repositoryMock.
+		On("ListDecisionsForUserId", mock.Anything, entity.UserID(1), &repository.DecisionCursor{UpdatedAt: nowTime, ID: int64(ExportPageSize)}, ExportPageSize).
+		Once().Return(lastPage, nil)
+
+	userService := NewUserServer(repositoryMock, &event_mock.MockLikeHub{})
+
+	stream := &exportStream{ctx: asUser(1)}
+	if err := userService.ExportUserData(&upv1.ExportUserDataRequest{UserId: 1}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(stream.sent), ExportPageSize+1)
+
+	var exported map[string]any
+	if err := json.Unmarshal([]byte(stream.sent[ExportPageSize].DecisionJson), &exported); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, exported["author_id"], float64(3))
+	assert.Equal(t, exported["recipient_id"], float64(1))
+	assert.Equal(t, exported["type"], "pass")
+
+	// The other users can't export the data
+	err := userService.ExportUserData(&upv1.ExportUserDataRequest{UserId: 1}, &exportStream{ctx: asUser(2)})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_EraseUser(t *testing.T) {
+	testCases := []struct {
+		name                string
+		ctx                 context.Context
+		eraseErr            error
+		expectedCode        codes.Code
+		expectedRequestedBy string
+		expectedForgotten   bool
+	}{
+		{
+			name:                "Users erase themselves",
+			ctx:                 asUser(1),
+			expectedCode:        codes.OK,
+			expectedRequestedBy: "user",
+			expectedForgotten:   true,
+		},
+		{
+			name:                "Services erase the users",
+			ctx:                 asService(),
+			expectedCode:        codes.OK,
+			expectedRequestedBy: "service",
+			expectedForgotten:   true,
+		},
+		{
+			name:                "Unknown or erased user",
+			ctx:                 asUser(1),
+			eraseErr:            domainError.NewUserNotFoundErr(),
+			expectedCode:        codes.NotFound,
+			expectedRequestedBy: "user",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repositoryMock := &repository_mock.MockExplorerRepository{}
+			likeHubMock := &event_mock.MockLikeHub{}
+
+			var erasure *entity.UserErasure
+
+			repositoryMock.
+				On("EraseUser", mock.Anything, entity.UserID(1), mock.AnythingOfType("*entity.UserErasure")).
+				Once().
+				Run(func(args mock.Arguments) {
+					erasure = args.Get(2).(*entity.UserErasure)
+					erasure.DecisionsErased = 3
+				}).
+				Return(testCase.eraseErr)
+
+			if testCase.expectedForgotten {
+				likeHubMock.On("Forget", entity.UserID(1)).Once()
+			}
+
+			response, err := NewUserServer(repositoryMock, likeHubMock).EraseUser(testCase.ctx, &upv1.EraseUserRequest{UserId: 1})
+
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+			assert.Equal(t, erasure.RequestedBy, testCase.expectedRequestedBy)
+
+			if testCase.expectedCode == codes.OK {
+				assert.Equal(t, response.DecisionsErased, uint64(3))
+			}
+
+			repositoryMock.AssertExpectations(t)
+			likeHubMock.AssertExpectations(t)
+		})
+	}
+
+	// The other users can't erase the account
+	_, err := NewUserServer(&repository_mock.MockExplorerRepository{}, &event_mock.MockLikeHub{}).EraseUser(asUser(2), &upv1.EraseUserRequest{UserId: 1})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+}
@@ -0,0 +1,358 @@
+// Package config loads the settings of the server. The values are read, from the lowest to the highest
+// priority, from the defaults, an optional YAML file, the environment and the command line flags.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+const (
+	ReadModelTable       = "table"       // The likes are read from the decisions table
+	ReadModelProjections = "projections" // The likes are read from the in-memory projections of the decision log
+)
+
+type Config struct {
+	ListenAddress string `yaml:"listen_address"`
+	// The admin HTTP server exposes the metrics on /metrics, it is disabled when empty
+	AdminListenAddress string          `yaml:"admin_listen_address"`
+	Storage            string          `yaml:"storage"`         // "postgres" or "memory"
+	SeedDummyData      bool            `yaml:"seed_dummy_data"` // Inserts the dummy dataset on start up, for local development only
+	Database           DatabaseConfig  `yaml:"database"`
+	Timeouts           TimeoutsConfig  `yaml:"timeouts"`
+	Decisions          DecisionsConfig `yaml:"decisions"`
+	Outbox             OutboxConfig    `yaml:"outbox"`
+	Health             HealthConfig    `yaml:"health"`
+	Auth               AuthConfig      `yaml:"auth"`
+	TLS                TLSConfig       `yaml:"tls"`
+	Tracing            TracingConfig   `yaml:"tracing"`
+	Log                LogConfig       `yaml:"log"`
+}
+
+type DatabaseConfig struct {
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	User         string `yaml:"user"`
+	Name         string `yaml:"name"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"` // The password is read from this file, i.e. a docker secret
+	SSLMode      string `yaml:"ssl_mode"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"` // 0 means unlimited
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"` // 0 means connections are reused forever
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type TimeoutsConfig struct {
+	Connection time.Duration `yaml:"connection"` // Time allowed to establish a client connection
+	Request    time.Duration `yaml:"request"`    // Deadline of the unary calls, the streams are not limited
+	Shutdown   time.Duration `yaml:"shutdown"`   // Time allowed to the running calls to complete on shutdown
+}
+
+type DecisionsConfig struct {
+	UndoWindow time.Duration `yaml:"undo_window"` // How long after a decision its author can undo it, 0 disables the undo
+	ReadModel  string        `yaml:"read_model"`  // "table" or "projections"
+}
+
+const (
+	OutboxPublisherInProcess = "inprocess" // Handlers of the same process, none by default so the events are dropped
+	OutboxPublisherFile      = "file"      // JSON lines appended to a file
+	OutboxPublisherNATS      = "nats"
+)
+
+// The events of the outbox are relayed to the publisher, written with the decisions in the same transaction
+type OutboxConfig struct {
+	Publisher         string        `yaml:"publisher"` // "inprocess", "file" or "nats"
+	File              string        `yaml:"file"`      // Required by the file publisher
+	NATSURL           string        `yaml:"nats_url"`
+	NATSEmbedded      bool          `yaml:"nats_embedded"`       // Starts a NATS server in the process on the address of the url, for local development
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix"` // The events are published on <prefix>.<type>
+	PollInterval      time.Duration `yaml:"poll_interval"`       // How often the relay checks the outbox once it is empty
+}
+
+type HealthConfig struct {
+	ProbeInterval time.Duration `yaml:"probe_interval"` // How often the dependencies, i.e. the database, are checked
+	ProbeTimeout  time.Duration `yaml:"probe_timeout"`
+}
+
+// The callers authenticate with JWTs signed with a key of the JWKS file
+type AuthConfig struct {
+	JWKSFile string        `yaml:"jwks_file"`
+	Issuer   string        `yaml:"issuer"`   // Expected iss claim, not checked when empty
+	Audience string        `yaml:"audience"` // Expected aud claim, not checked when empty
+	Leeway   time.Duration `yaml:"leeway"`   // Clock skew allowed on the token times
+	Insecure bool          `yaml:"insecure"` // Every call runs as an admin, for local development only
+}
+
+// Serving without a certificate means plaintext. With a client CA the clients must present a certificate signed by it.
+type TLSConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	// How often the files are checked for a rotation, the new certificates apply to the new connections
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file" // Same format as stdout, handy to collect the spans offline
+	TracingExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter"` // "none", "stdout", "file" or "otlp"
+	File         string  `yaml:"file"`     // Required by the file exporter
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	OTLPInsecure bool    `yaml:"otlp_insecure"`
+	SampleRatio  float64 `yaml:"sample_ratio"` // The traces started by the callers keep their sampling decision
+}
+
+// The logs are JSON lines on stderr
+type LogConfig struct {
+	Level string `yaml:"level"` // "debug", "info", "warn" or "error"
+}
+
+// Returns the slog level of the setting, the level is checked by Validate
+func (c LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(c.Level))
+
+	return level
+}
+
+func Default() *Config {
+	return &Config{
+		ListenAddress:      ":9001",
+		AdminListenAddress: ":9090",
+		Storage:            StoragePostgres,
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			MaxOpenConns:    20,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Timeouts: TimeoutsConfig{
+			Connection: 10 * time.Second,
+			Request:    5 * time.Second,
+			Shutdown:   20 * time.Second,
+		},
+		Decisions: DecisionsConfig{
+			UndoWindow: 5 * time.Minute,
+			ReadModel:  ReadModelTable,
+		},
+		Outbox: OutboxConfig{
+			Publisher:         OutboxPublisherInProcess,
+			NATSURL:           "nats://127.0.0.1:4222",
+			NATSSubjectPrefix: "explore.decisions",
+			PollInterval:      time.Second,
+		},
+		Health: HealthConfig{
+			ProbeInterval: 5 * time.Second,
+			ProbeTimeout:  2 * time.Second,
+		},
+		Auth: AuthConfig{
+			Leeway: 30 * time.Second,
+		},
+		TLS: TLSConfig{
+			ReloadInterval: time.Minute,
+		},
+		Tracing: TracingConfig{
+			Exporter:     TracingExporterNone,
+			OTLPEndpoint: "localhost:4317",
+			SampleRatio:  1,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// Returns every problem found in the configuration at once, so they can all be fixed in one go
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.ListenAddress); err != nil {
+		errs = append(errs, fmt.Errorf("listen address %q must be a host:port: %w", c.ListenAddress, err))
+	}
+
+	if c.AdminListenAddress != "" {
+		if _, _, err := net.SplitHostPort(c.AdminListenAddress); err != nil {
+			errs = append(errs, fmt.Errorf("admin listen address %q must be a host:port: %w", c.AdminListenAddress, err))
+		} else if c.AdminListenAddress == c.ListenAddress {
+			errs = append(errs, errors.New("admin listen address must differ from the listen address"))
+		}
+	}
+
+	switch c.Storage {
+	case StorageMemory:
+	case StoragePostgres:
+		errs = append(errs, c.Database.validate()...)
+	default:
+		errs = append(errs, fmt.Errorf("storage %q must be %q or %q", c.Storage, StoragePostgres, StorageMemory))
+	}
+
+	if c.Timeouts.Connection <= 0 {
+		errs = append(errs, errors.New("connection timeout must be positive"))
+	}
+
+	if c.Timeouts.Request < 0 {
+		errs = append(errs, errors.New("request timeout can't be negative"))
+	}
+
+	if c.Timeouts.Shutdown < 0 {
+		errs = append(errs, errors.New("shutdown timeout can't be negative"))
+	}
+
+	if c.Decisions.UndoWindow < 0 {
+		errs = append(errs, errors.New("undo window can't be negative"))
+	}
+
+	if c.Decisions.ReadModel != ReadModelTable && c.Decisions.ReadModel != ReadModelProjections {
+		errs = append(errs, fmt.Errorf("decisions read model %q must be %q or %q", c.Decisions.ReadModel, ReadModelTable, ReadModelProjections))
+	}
+
+	errs = append(errs, c.Outbox.validate()...)
+
+	if c.Health.ProbeInterval <= 0 || c.Health.ProbeTimeout <= 0 {
+		errs = append(errs, errors.New("health probe interval and timeout must be positive"))
+	}
+
+	if c.Auth.JWKSFile == "" && !c.Auth.Insecure {
+		errs = append(errs, errors.New("auth jwks file is required, or auth insecure for local development"))
+	}
+
+	if c.Auth.JWKSFile != "" && c.Auth.Insecure {
+		errs = append(errs, errors.New("auth jwks file and auth insecure can't both be set"))
+	}
+
+	if c.Auth.Leeway < 0 {
+		errs = append(errs, errors.New("auth leeway can't be negative"))
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls cert file and key file must be set together"))
+	}
+
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		errs = append(errs, errors.New("tls client ca file requires a cert file and a key file"))
+	}
+
+	if c.TLS.ReloadInterval < 0 {
+		errs = append(errs, errors.New("tls reload interval can't be negative"))
+	}
+
+	errs = append(errs, c.Tracing.validate()...)
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log level %q must be debug, info, warn or error", c.Log.Level))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c TracingConfig) validate() []error {
+	var errs []error
+
+	switch c.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterFile:
+		if c.File == "" {
+			errs = append(errs, errors.New("tracing file is required by the file exporter"))
+		}
+	case TracingExporterOTLP:
+		if c.OTLPEndpoint == "" {
+			errs = append(errs, errors.New("tracing otlp endpoint is required by the otlp exporter"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing exporter %q must be %q, %q, %q or %q", c.Exporter,
+			TracingExporterNone, TracingExporterStdout, TracingExporterFile, TracingExporterOTLP))
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing sample ratio must be between 0 and 1"))
+	}
+
+	return errs
+}
+
+func (c OutboxConfig) validate() []error {
+	var errs []error
+
+	switch c.Publisher {
+	case OutboxPublisherInProcess:
+	case OutboxPublisherFile:
+		if c.File == "" {
+			errs = append(errs, errors.New("outbox file is required by the file publisher"))
+		}
+	case OutboxPublisherNATS:
+		if parsedURL, err := url.Parse(c.NATSURL); err != nil || parsedURL.Host == "" {
+			errs = append(errs, fmt.Errorf("outbox nats url %q must be a url, i.e. nats://127.0.0.1:4222", c.NATSURL))
+		}
+
+		if c.NATSSubjectPrefix == "" {
+			errs = append(errs, errors.New("outbox nats subject prefix is required by the nats publisher"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("outbox publisher %q must be %q, %q or %q", c.Publisher,
+			OutboxPublisherInProcess, OutboxPublisherFile, OutboxPublisherNATS))
+	}
+
+	if c.PollInterval <= 0 {
+		errs = append(errs, errors.New("outbox poll interval must be positive"))
+	}
+
+	return errs
+}
+
+func (c DatabaseConfig) validate() []error {
+	var errs []error
+
+	if c.Host == "" {
+		errs = append(errs, errors.New("database host is required"))
+	}
+
+	if c.Port <= 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database port %d is not a valid port", c.Port))
+	}
+
+	if c.User == "" {
+		errs = append(errs, errors.New("database user is required"))
+	}
+
+	if c.Name == "" {
+		errs = append(errs, errors.New("database name is required"))
+	}
+
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database pool sizes can't be negative"))
+	}
+
+	if c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, errors.New("database max idle conns can't be greater than max open conns"))
+	}
+
+	if c.ConnMaxLifetime < 0 || c.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("database connection lifetimes can't be negative"))
+	}
+
+	return errs
+}
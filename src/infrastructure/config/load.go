@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Looks up an environment variable, os.LookupEnv outside of the tests
+type LookupEnvFunc func(key string) (string, bool)
+
+// A setting that can be overridden from the environment and from the command line
+type setting struct {
+	flag    string
+	env     string
+	usage   string
+	boolean bool // The flag can be set without a value, i.e. '-seed-dummy-data'
+	set     func(c *Config, value string) error
+}
+
+// The POSTGRES_* variables are the ones of the postgres image so the same environment can be shared
+var settings = []setting{
+	stringSetting("listen-address", "EXPLORER_LISTEN_ADDRESS", "address the gRPC server listens on", func(c *Config) *string { return &c.ListenAddress }),
+	stringSetting("admin-listen-address", "EXPLORER_ADMIN_LISTEN_ADDRESS", "address the admin HTTP server with the /metrics endpoint listens on, empty disables it", func(c *Config) *string { return &c.AdminListenAddress }),
+	stringSetting("storage", "EXPLORER_STORAGE", "storage of the decisions: postgres or memory", func(c *Config) *string { return &c.Storage }),
+	boolSetting("seed-dummy-data", "EXPLORER_SEED_DUMMY_DATA", "insert the dummy dataset on start up", func(c *Config) *bool { return &c.SeedDummyData }),
+
+	stringSetting("db-host", "POSTGRES_HOST", "database host", func(c *Config) *string { return &c.Database.Host }),
+	intSetting("db-port", "POSTGRES_PORT", "database port", func(c *Config) *int { return &c.Database.Port }),
+	stringSetting("db-user", "POSTGRES_USER", "database user", func(c *Config) *string { return &c.Database.User }),
+	stringSetting("db-name", "POSTGRES_DB", "database name", func(c *Config) *string { return &c.Database.Name }),
+	stringSetting("db-password-file", "POSTGRES_PASSWORD_FILE", "file containing the database password, i.e. a docker secret", func(c *Config) *string { return &c.Database.PasswordFile }),
+	stringSetting("db-ssl-mode", "POSTGRES_SSLMODE", "database ssl mode", func(c *Config) *string { return &c.Database.SSLMode }),
+	intSetting("db-max-open-conns", "EXPLORER_DB_MAX_OPEN_CONNS", "maximum number of open database connections, 0 is unlimited", func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intSetting("db-max-idle-conns", "EXPLORER_DB_MAX_IDLE_CONNS", "maximum number of idle database connections", func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationSetting("db-conn-max-lifetime", "EXPLORER_DB_CONN_MAX_LIFETIME", "maximum time a database connection is reused", func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime }),
+	durationSetting("db-conn-max-idle-time", "EXPLORER_DB_CONN_MAX_IDLE_TIME", "maximum time a database connection stays idle", func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime }),
+
+	durationSetting("connection-timeout", "EXPLORER_CONNECTION_TIMEOUT", "time allowed to establish a client connection", func(c *Config) *time.Duration { return &c.Timeouts.Connection }),
+	durationSetting("request-timeout", "EXPLORER_REQUEST_TIMEOUT", "deadline of the unary calls, 0 disables it", func(c *Config) *time.Duration { return &c.Timeouts.Request }),
+	durationSetting("shutdown-timeout", "EXPLORER_SHUTDOWN_TIMEOUT", "time allowed to the running calls to complete on shutdown", func(c *Config) *time.Duration { return &c.Timeouts.Shutdown }),
+
+	durationSetting("undo-window", "EXPLORER_UNDO_WINDOW", "how long after a decision its author can undo it, 0 disables the undo", func(c *Config) *time.Duration { return &c.Decisions.UndoWindow }),
+	stringSetting("decisions-read-model", "EXPLORER_DECISIONS_READ_MODEL", "where the likes and the matches are read from: table or projections", func(c *Config) *string { return &c.Decisions.ReadModel }),
+
+	stringSetting("outbox-publisher", "EXPLORER_OUTBOX_PUBLISHER", "where the decision and match events are published: inprocess, file or nats", func(c *Config) *string { return &c.Outbox.Publisher }),
+	stringSetting("outbox-file", "EXPLORER_OUTBOX_FILE", "file the events are appended to with the file publisher", func(c *Config) *string { return &c.Outbox.File }),
+	stringSetting("outbox-nats-url", "EXPLORER_OUTBOX_NATS_URL", "NATS server the events are published to with the nats publisher", func(c *Config) *string { return &c.Outbox.NATSURL }),
+	boolSetting("outbox-nats-embedded", "EXPLORER_OUTBOX_NATS_EMBEDDED", "start a NATS server in the process on the address of the nats url, local development only", func(c *Config) *bool { return &c.Outbox.NATSEmbedded }),
+	stringSetting("outbox-nats-subject-prefix", "EXPLORER_OUTBOX_NATS_SUBJECT_PREFIX", "the events are published on <prefix>.<type>", func(c *Config) *string { return &c.Outbox.NATSSubjectPrefix }),
+	durationSetting("outbox-poll-interval", "EXPLORER_OUTBOX_POLL_INTERVAL", "how often the relay checks the outbox once it is empty", func(c *Config) *time.Duration { return &c.Outbox.PollInterval }),
+
+	durationSetting("health-probe-interval", "EXPLORER_HEALTH_PROBE_INTERVAL", "how often the dependencies of the services are checked", func(c *Config) *time.Duration { return &c.Health.ProbeInterval }),
+	durationSetting("health-probe-timeout", "EXPLORER_HEALTH_PROBE_TIMEOUT", "deadline of a dependency check", func(c *Config) *time.Duration { return &c.Health.ProbeTimeout }),
+
+	stringSetting("auth-jwks-file", "EXPLORER_AUTH_JWKS_FILE", "JWKS file with the keys the tokens are signed with", func(c *Config) *string { return &c.Auth.JWKSFile }),
+	stringSetting("auth-issuer", "EXPLORER_AUTH_ISSUER", "expected issuer of the tokens", func(c *Config) *string { return &c.Auth.Issuer }),
+	stringSetting("auth-audience", "EXPLORER_AUTH_AUDIENCE", "expected audience of the tokens", func(c *Config) *string { return &c.Auth.Audience }),
+	durationSetting("auth-leeway", "EXPLORER_AUTH_LEEWAY", "clock skew allowed on the token times", func(c *Config) *time.Duration { return &c.Auth.Leeway }),
+	boolSetting("auth-insecure", "EXPLORER_AUTH_INSECURE", "run every call as an admin without token, local development only", func(c *Config) *bool { return &c.Auth.Insecure }),
+
+	stringSetting("tls-cert-file", "EXPLORER_TLS_CERT_FILE", "server certificate, the server is plaintext without it", func(c *Config) *string { return &c.TLS.CertFile }),
+	stringSetting("tls-key-file", "EXPLORER_TLS_KEY_FILE", "server private key", func(c *Config) *string { return &c.TLS.KeyFile }),
+	stringSetting("tls-client-ca-file", "EXPLORER_TLS_CLIENT_CA_FILE", "CA the client certificates must be signed by", func(c *Config) *string { return &c.TLS.ClientCAFile }),
+	durationSetting("tls-reload-interval", "EXPLORER_TLS_RELOAD_INTERVAL", "how often the certificate files are checked for a rotation", func(c *Config) *time.Duration { return &c.TLS.ReloadInterval }),
+
+	stringSetting("tracing-exporter", "EXPLORER_TRACING_EXPORTER", "where the traces are sent: none, stdout, file or otlp", func(c *Config) *string { return &c.Tracing.Exporter }),
+	stringSetting("tracing-file", "EXPLORER_TRACING_FILE", "file the spans are appended to with the file exporter", func(c *Config) *string { return &c.Tracing.File }),
+	stringSetting("tracing-otlp-endpoint", "EXPLORER_TRACING_OTLP_ENDPOINT", "host:port of the OTLP gRPC collector", func(c *Config) *string { return &c.Tracing.OTLPEndpoint }),
+	boolSetting("tracing-otlp-insecure", "EXPLORER_TRACING_OTLP_INSECURE", "send the spans to the collector without TLS", func(c *Config) *bool { return &c.Tracing.OTLPInsecure }),
+	floatSetting("tracing-sample-ratio", "EXPLORER_TRACING_SAMPLE_RATIO", "ratio of the new traces that are sampled, from 0 to 1", func(c *Config) *float64 { return &c.Tracing.SampleRatio }),
+
+	stringSetting("log-level", "EXPLORER_LOG_LEVEL", "minimum level of the logs: debug, info, warn or error", func(c *Config) *string { return &c.Log.Level }),
+}
+
+// Loads the configuration from the command line arguments (without the program name) and the environment.
+// The flags come before the subcommand, the remaining arguments are returned, i.e. 'server -config x.yaml migrate up'.
+// The database password is deliberately not a flag, the command lines are visible to every user of the host.
+func Load(args []string, lookupEnv LookupEnvFunc) (*Config, []string, error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	configFile := flags.String("config", "", "YAML configuration file, also EXPLORER_CONFIG_FILE")
+
+	flagValues := map[string]string{}
+	for _, s := range settings {
+		usage := s.usage + ", also " + s.env
+		record := func(value string) error {
+			flagValues[s.flag] = value
+			return nil
+		}
+
+		if s.boolean {
+			flags.BoolFunc(s.flag, usage, record)
+		} else {
+			flags.Func(s.flag, usage, record)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			flags.SetOutput(os.Stderr)
+			flags.PrintDefaults()
+		}
+
+		return nil, nil, fmt.Errorf("error on parsing the flags: %w", err)
+	}
+
+	if *configFile == "" {
+		*configFile, _ = lookupEnv("EXPLORER_CONFIG_FILE")
+	}
+
+	cfg := Default()
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok {
+			if err := s.set(cfg, value); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s: %w", s.env, err))
+			}
+		}
+	}
+
+	// The password is the only setting without a flag
+	if password, ok := lookupEnv("POSTGRES_PASSWORD"); ok {
+		cfg.Database.Password = password
+	}
+
+	for _, s := range settings {
+		if value, ok := flagValues[s.flag]; ok {
+			if err := s.set(cfg, value); err != nil {
+				errs = append(errs, fmt.Errorf("invalid -%s: %w", s.flag, err))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, err
+	}
+
+	if err := loadSecrets(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("error, invalid configuration: %w", err)
+	}
+
+	return cfg, flags.Args(), nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error on opening the configuration file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true) // A typo in a key must not be silently ignored
+
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error on reading the configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Reads the secrets stored in files
+func loadSecrets(cfg *Config) error {
+	if cfg.Database.PasswordFile == "" {
+		return nil
+	}
+
+	if cfg.Database.Password != "" {
+		return errors.New("error, the database password and the database password file can't both be set")
+	}
+
+	password, err := os.ReadFile(cfg.Database.PasswordFile)
+	if err != nil {
+		return fmt.Errorf("error on reading the database password file: %w", err)
+	}
+
+	// The secret files usually end with a new line
+	cfg.Database.Password = strings.TrimRight(string(password), "\r\n")
+
+	return nil
+}
+
+func stringSetting(flag string, env string, usage string, field func(c *Config) *string) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intSetting(flag string, env string, usage string, field func(c *Config) *int) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func floatSetting(flag string, env string, usage string, field func(c *Config) *float64) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func boolSetting(flag string, env string, usage string, field func(c *Config) *bool) setting {
+	return setting{flag: flag, env: env, usage: usage, boolean: true, set: func(c *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func durationSetting(flag string, env string, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration, i.e. 5s", value)
+		}
+
+		*field(c) = parsed
+		return nil
+	}}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// Builds a LookupEnvFunc reading from a map instead of the process environment
+func envOf(values map[string]string) LookupEnvFunc {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+var databaseEnv = map[string]string{
+	"POSTGRES_USER":          "testingUser",
+	"POSTGRES_DB":            "explorer",
+	"EXPLORER_AUTH_INSECURE": "true",
+}
+
+func Test_Load_Precedence(t *testing.T) {
+	configFile := writeFile(t, "config.yaml", `
+listen_address: ":7000"
+seed_dummy_data: false
+database:
+  host: file-host
+  port: 6000
+  max_open_conns: 40
+timeouts:
+  request: 2s
+decisions:
+  undo_window: 30s
+  read_model: projections
+outbox:
+  publisher: nats
+  nats_embedded: true
+`)
+
+	env := map[string]string{
+		"EXPLORER_CONFIG_FILE":     configFile,
+		"POSTGRES_HOST":            "env-host",
+		"POSTGRES_PASSWORD":        "secret",
+		"EXPLORER_REQUEST_TIMEOUT": "3s",
+	}
+	for key, value := range databaseEnv {
+		env[key] = value
+	}
+
+	cfg, args, err := Load([]string{"-listen-address", ":8000", "-seed-dummy-data", "migrate", "up"}, envOf(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The flags override the environment, which overrides the file, which overrides the defaults
+	assert.Equal(t, cfg.ListenAddress, ":8000")
+	assert.Equal(t, cfg.SeedDummyData, true)
+	assert.Equal(t, cfg.Database.Host, "env-host")
+	assert.Equal(t, cfg.Database.Port, 6000)
+	assert.Equal(t, cfg.Database.MaxOpenConns, 40)
+	assert.Equal(t, cfg.Database.MaxIdleConns, Default().Database.MaxIdleConns)
+	assert.Equal(t, cfg.Database.Password, "secret")
+	assert.Equal(t, cfg.Timeouts.Request, 3*time.Second)
+	assert.Equal(t, cfg.Timeouts.Connection, Default().Timeouts.Connection)
+	assert.Equal(t, cfg.Decisions.UndoWindow, 30*time.Second)
+	assert.Equal(t, cfg.Decisions.ReadModel, ReadModelProjections)
+	assert.Equal(t, cfg.Outbox.Publisher, OutboxPublisherNATS)
+	assert.Equal(t, cfg.Outbox.NATSEmbedded, true)
+	assert.Equal(t, cfg.Outbox.NATSURL, Default().Outbox.NATSURL)
+
+	// The subcommand and its arguments are left to the caller
+	assert.Equal(t, args, []string{"migrate", "up"})
+}
+
+func Test_Load_PasswordFile(t *testing.T) {
+	passwordFile := writeFile(t, "db_password", "from a secret\n")
+
+	env := map[string]string{"POSTGRES_PASSWORD_FILE": passwordFile}
+	for key, value := range databaseEnv {
+		env[key] = value
+	}
+
+	cfg, _, err := Load(nil, envOf(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cfg.Database.Password, "from a secret")
+
+	// Setting both is ambiguous
+	env["POSTGRES_PASSWORD"] = "secret"
+
+	_, _, err = Load(nil, envOf(env))
+	if err == nil {
+		t.Fatal("expected an error when the password and the password file are both set")
+	}
+}
+
+func Test_Load_Errors(t *testing.T) {
+	unknownKeyFile := writeFile(t, "config.yaml", "listen_adress: \":7000\"\n")
+
+	testCases := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		expectedError string
+	}{
+		{
+			name:          "Database settings are required with postgres",
+			env:           map[string]string{},
+			expectedError: "database user is required",
+		},
+		{
+			name:          "Database settings are not required in memory",
+			env:           map[string]string{"EXPLORER_STORAGE": "memory", "EXPLORER_AUTH_JWKS_FILE": "jwks.json"},
+			expectedError: "",
+		},
+		{
+			name:          "Unknown storage",
+			args:          []string{"-storage", "mysql"},
+			env:           databaseEnv,
+			expectedError: `storage "mysql" must be`,
+		},
+		{
+			name:          "Invalid environment value",
+			env:           map[string]string{"EXPLORER_STORAGE": "memory", "EXPLORER_AUTH_INSECURE": "true", "EXPLORER_REQUEST_TIMEOUT": "5"},
+			expectedError: "invalid EXPLORER_REQUEST_TIMEOUT",
+		},
+		{
+			name:          "Invalid flag value",
+			args:          []string{"-db-max-open-conns", "many"},
+			env:           databaseEnv,
+			expectedError: "invalid -db-max-open-conns",
+		},
+		{
+			name:          "Unknown flag",
+			args:          []string{"-db-password", "secret"},
+			env:           databaseEnv,
+			expectedError: "flag provided but not defined",
+		},
+		{
+			name:          "Invalid listen address",
+			args:          []string{"-listen-address", "9001"},
+			env:           databaseEnv,
+			expectedError: "must be a host:port",
+		},
+		{
+			name:          "Admin on the gRPC address",
+			args:          []string{"-admin-listen-address", ":9001"},
+			env:           databaseEnv,
+			expectedError: "admin listen address must differ",
+		},
+		{
+			name:          "File exporter without file",
+			args:          []string{"-tracing-exporter", "file"},
+			env:           databaseEnv,
+			expectedError: "tracing file is required",
+		},
+		{
+			name:          "Invalid sample ratio",
+			args:          []string{"-tracing-sample-ratio", "1.5"},
+			env:           databaseEnv,
+			expectedError: "sample ratio must be between 0 and 1",
+		},
+		{
+			name:          "Negative undo window",
+			args:          []string{"-undo-window", "-1m"},
+			env:           databaseEnv,
+			expectedError: "undo window can't be negative",
+		},
+		{
+			name:          "Unknown decisions read model",
+			args:          []string{"-decisions-read-model", "cache"},
+			env:           databaseEnv,
+			expectedError: `decisions read model "cache" must be`,
+		},
+		{
+			name:          "Outbox file publisher without file",
+			args:          []string{"-outbox-publisher", "file"},
+			env:           databaseEnv,
+			expectedError: "outbox file is required",
+		},
+		{
+			name:          "Unknown outbox publisher",
+			args:          []string{"-outbox-publisher", "kafka"},
+			env:           databaseEnv,
+			expectedError: `outbox publisher "kafka" must be`,
+		},
+		{
+			name:          "Invalid log level",
+			args:          []string{"-log-level", "verbose"},
+			env:           databaseEnv,
+			expectedError: `log level "verbose" must be`,
+		},
+		{
+			name:          "Pool with more idle than open connections",
+			args:          []string{"-db-max-open-conns", "2", "-db-max-idle-conns", "3"},
+			env:           databaseEnv,
+			expectedError: "max idle conns can't be greater",
+		},
+		{
+			name:          "Authentication is required",
+			env:           map[string]string{"EXPLORER_STORAGE": "memory"},
+			expectedError: "auth jwks file is required",
+		},
+		{
+			name:          "Authentication can't be both verified and insecure",
+			args:          []string{"-auth-jwks-file", "jwks.json"},
+			env:           databaseEnv,
+			expectedError: "can't both be set",
+		},
+		{
+			name:          "Certificate without key",
+			args:          []string{"-tls-cert-file", "server.crt"},
+			env:           databaseEnv,
+			expectedError: "cert file and key file must be set together",
+		},
+		{
+			name:          "Unknown key in the file",
+			args:          []string{"-config", unknownKeyFile},
+			env:           databaseEnv,
+			expectedError: "field listen_adress not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := Load(testCase.args, envOf(testCase.env))
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Fatalf("expected an error containing %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
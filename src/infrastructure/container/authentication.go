@@ -0,0 +1,36 @@
+package container
+
+import (
+	"log"
+
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/interceptor"
+	"github.com/lokker96/grpc_project/infrastructure/jwtauth"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Builds the authenticator of the gRPC calls from the auth settings. The health checks are public
+// so the orchestrators can probe the server without a token.
+func NewAuthenticator(authConfig config.AuthConfig) (interceptor.Authenticator, error) {
+	if authConfig.Insecure {
+		log.Print("warning: authentication is disabled, every call runs as an admin")
+		return interceptor.InsecureAuthenticator(), nil
+	}
+
+	keySet, err := jwtauth.LoadKeySet(authConfig.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := jwtauth.NewVerifier(keySet, jwtauth.VerifierConfig{
+		Issuer:   authConfig.Issuer,
+		Audience: authConfig.Audience,
+		Leeway:   authConfig.Leeway,
+	})
+
+	return interceptor.BearerAuthenticator(
+		verifier,
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
+	), nil
+}
@@ -1,59 +1,218 @@
 package container
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
 	"github.com/lokker96/grpc_project/domain/service"
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/healthcheck"
+	"github.com/lokker96/grpc_project/infrastructure/metrics"
+	"github.com/lokker96/grpc_project/infrastructure/outbox"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/memory"
 	"github.com/lokker96/grpc_project/infrastructure/persistence/postgres"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/postgres/migration"
+	"github.com/lokker96/grpc_project/infrastructure/projection"
+	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	upv1 "github.com/lokker96/grpc_project/infrastructure/proto/user/v1"
+	"github.com/lokker96/grpc_project/infrastructure/pubsub"
+	"github.com/lokker96/grpc_project/infrastructure/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/health"
+	"gorm.io/gorm"
 )
 
 // Define the Container structure
 // This is useful for setting up the internal container infrastructure
 // and hide complexity from the main function
 type Container struct {
-	ExplorerServer *service.ExploreServer
+	ExplorerServer   *service.ExploreServer   // Serves the v2 API
+	ExplorerServerV1 *service.ExploreServerV1 // Serves the v1 API on top of the v2 one
+	UserServer       *service.UserServer      // Serves the lifecycle of the user accounts
+	LikeHub          *pubsub.LikeHub
+	OutboxRelay      *outbox.Relay               // Started by the caller, publishes the decision and match events
+	LikesProjection  *projection.LikesProjection // Started by the caller, nil unless the projections serve the likes
+	HealthServer     *health.Server
+	HealthMonitor    *healthcheck.Monitor
+	MetricsRegistry  *prometheus.Registry   // Served on /metrics by the admin server
+	ServerMetrics    *metrics.ServerMetrics // gRPC interceptors counting and timing the calls
+	TracerProvider   trace.TracerProvider
+	db               *gorm.DB // nil with the in-memory storage
+	shutdownTracing  func(context.Context) error
+	closePublisher   func() error
 }
 
-// NewContainer function creates and returns a new Container instance
-func NewContainer() (*Container, error) {
-
-	// This can be stored using docker secrets or 3rd party solution
-	dbPassword := "testingPassword"
+// Forgets the erased users in every component keeping something about them in memory
+type likeForgetters []event.LikeForgetter
 
-	// Setup the timezone for the database
-	zone, _ := time.Now().Zone()
+func (f likeForgetters) Forget(userID entity.UserID) {
+	for _, forgetter := range f {
+		forgetter.Forget(userID)
+	}
+}
 
-	// Prepare connection string for database connection
-	dbDSN := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=%s",
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_USER"),
-		string(dbPassword),
-		os.Getenv("POSTGRES_DB"),
-		os.Getenv("POSTGRES_PORT"),
-		zone,
-	)
+// Builds the explorer repository selected by the storage setting, the database connection is nil in memory
+func newExplorerRepository(cfg *config.Config) (repository.ExplorerRepository, *gorm.DB, error) {
+	if cfg.Storage == config.StorageMemory {
+		return memory.NewExplorerRepository(), nil, nil
+	}
 
 	// Creatre new db connection using gorm
-	dbConnection, err := NewDBConnection(dbDSN)
+	dbConnection, err := NewDBConnection(cfg.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error on creating new db connection: %w", err)
+	}
+
+	// Refuse to start when the schema is behind the code, the migrations are applied with the migrate command
+	migrator, err := migration.NewMigrator(dbConnection)
 	if err != nil {
-		return nil, fmt.Errorf("error on creating new db connection: %w", err)
+		return nil, nil, fmt.Errorf("error on loading migrations: %w", err)
+	}
+
+	if err := migrator.EnsureUpToDate(context.Background()); err != nil {
+		return nil, nil, err
 	}
 
-	// Build new explorer repository with the db connection created before
-	explorerRepository := postgres.NewExplorerRepository(dbConnection)
+	return postgres.NewExplorerRepository(dbConnection), dbConnection, nil
+}
+
+// NewContainer function creates and returns a new Container instance
+func NewContainer(cfg *config.Config) (*Container, error) {
+	// Build new explorer repository, the in-memory one is handy for local development without a database
+	explorerRepository, dbConnection, err := newExplorerRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider, shutdownTracing, err := newTracerProvider(cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every repository query is traced and timed, the business counters are fed by the explorer server
+	explorerRepository = tracing.NewExplorerRepository(explorerRepository, tracerProvider)
+
+	metricsRegistry := metrics.NewRegistry()
+	explorerRepository = metrics.NewExplorerRepository(explorerRepository, metricsRegistry)
 
 	// Create the explorer server using the gRPC server code and attach the explorer
 	// repository that implements the database routines for accessing the data using gorm
-	explorerServer := service.NewExplorerServer(explorerRepository)
+	// The like hub pushes the new likes and matches to the WatchLikes streams
+	likeHub := pubsub.NewLikeHub(pubsub.DefaultLikeHubConfig())
+
+	explorerServer := service.NewExplorerServer(explorerRepository, likeHub, metrics.NewDecisionRecorder(metricsRegistry))
+	explorerServer.SetUndoWindow(cfg.Decisions.UndoWindow)
+
+	// The erased users are forgotten by the like hub, and by the projections when they serve the likes
+	forgetters := likeForgetters{likeHub}
+
+	// Create some dummy data when the seed-dummy-data setting is on, for local development only
+	if cfg.SeedDummyData {
+		if err := explorerServer.BuildDummyDataset(context.Background()); err != nil {
+			return nil, fmt.Errorf("error on seeding the dummy dataset: %w", err)
+		}
+	}
+
+	// The projections replay the whole decision log on start up, then follow it in the background
+	var likesProjection *projection.LikesProjection
+	if cfg.Decisions.ReadModel == config.ReadModelProjections {
+		likesProjection = projection.NewLikesProjection(explorerRepository, projection.DefaultLikesProjectionConfig())
+		if err := likesProjection.Rebuild(context.Background()); err != nil {
+			return nil, fmt.Errorf("error on building the likes projection: %w", err)
+		}
+
+		explorerServer.SetLikesReadModel(likesProjection)
+		forgetters = append(forgetters, likesProjection)
+	}
 
-	// Create some dummy data - disable if you don't want it
-	explorerServer.BuildDummyDataset()
+	// The outbox is written by the repository with the decisions, the relay publishes it
+	publisher, closePublisher, err := newEventPublisher(cfg.Outbox)
+	if err != nil {
+		return nil, err
+	}
+
+	relayConfig := outbox.DefaultRelayConfig()
+	relayConfig.PollInterval = cfg.Outbox.PollInterval
+
+	// The health service reports the explore service as not serving when its database can't be reached.
+	// The monitor is started by the caller once the server is ready.
+	healthServer := health.NewServer()
+	healthMonitor := healthcheck.NewMonitor(healthServer, cfg.Health.ProbeInterval, cfg.Health.ProbeTimeout)
+
+	explorerProbes := make([]healthcheck.Probe, 0)
+	if dbConnection != nil {
+		explorerProbes = append(explorerProbes, databaseProbe(dbConnection))
+	}
+
+	healthMonitor.Register(ep.ExploreService_ServiceDesc.ServiceName, explorerProbes...)
+	healthMonitor.Register(epv2.ExploreService_ServiceDesc.ServiceName, explorerProbes...)
+	healthMonitor.Register(upv1.UserService_ServiceDesc.ServiceName, explorerProbes...)
 
 	// Return a new Container instance with its explorer server
 	return &Container{
-		ExplorerServer: explorerServer,
+		ExplorerServer:   explorerServer,
+		ExplorerServerV1: service.NewExplorerServerV1(explorerServer),
+		UserServer:       service.NewUserServer(explorerRepository, forgetters),
+		LikeHub:          likeHub,
+		OutboxRelay:      outbox.NewRelay(explorerRepository, publisher, relayConfig),
+		LikesProjection:  likesProjection,
+		HealthServer:     healthServer,
+		HealthMonitor:    healthMonitor,
+		MetricsRegistry:  metricsRegistry,
+		ServerMetrics:    metrics.NewServerMetrics(metricsRegistry),
+		TracerProvider:   tracerProvider,
+		shutdownTracing:  shutdownTracing,
+		closePublisher:   closePublisher,
+		db:               dbConnection,
 	}, nil
 }
+
+// Stops the outbox relay and the likes projection, flushes the pending spans and releases the publisher and the database connections,
+// called once the server doesn't run any call anymore
+func (c *Container) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var errs []error
+
+	// The events left in the outbox are published by the next relay to run
+	c.OutboxRelay.Stop()
+
+	if c.LikesProjection != nil {
+		c.LikesProjection.Stop()
+	}
+
+	if err := c.closePublisher(); err != nil {
+		errs = append(errs, fmt.Errorf("error on closing the outbox publisher: %w", err))
+	}
+
+	if err := c.shutdownTracing(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error on flushing the spans: %w", err))
+	}
+
+	if c.db != nil {
+		errs = append(errs, closeDB(c.db))
+	}
+
+	return errors.Join(errs...)
+}
+
+func closeDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("error on closing the db connection pool: %w", err)
+	}
+
+	return nil
+}
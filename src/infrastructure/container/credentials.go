@@ -0,0 +1,30 @@
+package container
+
+import (
+	"fmt"
+
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/tlsconfig"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Builds the transport credentials of the gRPC server: plaintext without a certificate, TLS with one
+// and mutual TLS when a client CA is configured. The files are reloaded when they rotate on disk.
+func NewServerCredentials(tlsConfig config.TLSConfig) (credentials.TransportCredentials, error) {
+	if !tlsConfig.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	serverTLSConfig, err := tlsconfig.NewServerConfig(tlsconfig.ServerConfig{
+		CertFile:       tlsConfig.CertFile,
+		KeyFile:        tlsConfig.KeyFile,
+		ClientCAFile:   tlsConfig.ClientCAFile,
+		ReloadInterval: tlsConfig.ReloadInterval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on loading the tls configuration: %w", err)
+	}
+
+	return credentials.NewTLS(serverTLSConfig), nil
+}
@@ -1,42 +1,73 @@
 package container
 
 import (
-	"github.com/lokker96/grpc_project/domain/entity"
-
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/healthcheck"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// Used to open a connection to a database with GORM and a posgres driver
-func NewDBConnection(dsn string) (*gorm.DB, error) {
+// Builds the connection string of the database from the configuration
+func DatabaseDSN(dbConfig config.DatabaseConfig) string {
+	// Setup the timezone for the database
+	zone, _ := time.Now().Zone()
 
-	// setup the entities used to build the tables in the DB
-	EntityTypes := []interface{}{
-		&entity.User{},
-		&entity.Decision{},
-	}
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		quoteDSNValue(dbConfig.Host),
+		quoteDSNValue(dbConfig.User),
+		quoteDSNValue(dbConfig.Password),
+		quoteDSNValue(dbConfig.Name),
+		dbConfig.Port,
+		dbConfig.SSLMode,
+		zone,
+	)
+}
 
+// Quotes a value of the connection string, the passwords read from a secret may contain spaces or quotes
+func quoteDSNValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// Used to open a connection to a database with GORM and a posgres driver, the pool is sized from the configuration.
+// The tables are not created here, they are managed by the versioned migrations
+// in 'infrastructure/persistence/postgres/migration' applied with the migrate command.
+func NewDBConnection(dbConfig config.DatabaseConfig) (*gorm.DB, error) {
 	// open the connection
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(DatabaseDSN(dbConfig)), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("error on opening db connection: %w", err)
 	}
 
-	// tables are dropped just for demonstration purposes, dropping tables in production would cause data to be lost.
-	for _, entityType := range EntityTypes {
-		if err := db.Migrator().DropTable(entityType); err != nil {
-			return nil, fmt.Errorf("error on dropping table: %w", err)
-		}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("error on configuring the db connection pool: %w", err)
 	}
 
-	// iterate through entities and use gorm tags to build tables
-	for _, entityType := range EntityTypes {
-		if err := db.AutoMigrate(entityType); err != nil {
-			return nil, fmt.Errorf("error on auto migrating table: %w", err)
-		}
-	}
+	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(dbConfig.ConnMaxIdleTime)
 
 	return db, nil
 }
+
+// Checks that the database can still be reached, used by the health service
+func databaseProbe(db *gorm.DB) healthcheck.Probe {
+	return healthcheck.Probe{
+		Name: "postgres",
+		Check: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
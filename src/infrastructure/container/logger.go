@@ -0,0 +1,16 @@
+package container
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/lokker96/grpc_project/infrastructure/config"
+)
+
+// Builds the JSON logger of the server, it is made the default logger so the standard log package writes through it too
+func NewLogger(logConfig config.LogConfig) *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: logConfig.SlogLevel()}))
+	slog.SetDefault(logger)
+
+	return logger
+}
@@ -0,0 +1,53 @@
+package container
+
+import (
+	"errors"
+
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/outbox"
+)
+
+// Builds the publisher selected by the outbox settings, starting the embedded NATS server when asked.
+// The returned function releases the publisher and stops the embedded server.
+func newEventPublisher(outboxConfig config.OutboxConfig) (event.EventPublisher, func() error, error) {
+	switch outboxConfig.Publisher {
+	case config.OutboxPublisherFile:
+		filePublisher, err := outbox.NewFilePublisher(outboxConfig.File)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return filePublisher, filePublisher.Close, nil
+	case config.OutboxPublisherNATS:
+		shutdownServer := func() {}
+
+		if outboxConfig.NATSEmbedded {
+			natsServer, err := outbox.StartEmbeddedNATSServer(outboxConfig.NATSURL)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			shutdownServer = natsServer.Shutdown
+		}
+
+		natsPublisher, err := outbox.NewNATSPublisher(outboxConfig.NATSURL, outboxConfig.NATSSubjectPrefix)
+		if err != nil {
+			shutdownServer()
+			return nil, nil, err
+		}
+
+		closePublisher := func() error {
+			err := natsPublisher.Close()
+			shutdownServer()
+
+			return err
+		}
+
+		return natsPublisher, closePublisher, nil
+	case config.OutboxPublisherInProcess:
+		return outbox.NewInProcessPublisher(), func() error { return nil }, nil
+	}
+
+	return nil, nil, errors.New("unknown outbox publisher " + outboxConfig.Publisher)
+}
@@ -0,0 +1,80 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/tracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const serviceName = "explore-server"
+
+// Builds the tracer provider with the exporter selected by the tracing settings. The returned function flushes
+// the pending spans and releases the exporter.
+func newTracerProvider(tracingConfig config.TracingConfig) (trace.TracerProvider, func(context.Context) error, error) {
+	// Nothing is recorded, the trace context of the callers is still available to the handlers
+	if tracingConfig.Exporter == config.TracingExporterNone {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	closeExporter := func() error { return nil }
+
+	switch tracingConfig.Exporter {
+	case config.TracingExporterStdout:
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exporter = stdoutExporter
+	case config.TracingExporterFile:
+		// One JSON span per line, the file is appended to so the spans of the previous runs are kept
+		file, err := os.OpenFile(tracingConfig.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error on opening the tracing file: %w", err)
+		}
+
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+
+		exporter = fileExporter
+		closeExporter = file.Close
+	case config.TracingExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(tracingConfig.OTLPEndpoint)}
+		if tracingConfig.OTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		// The connection to the collector is made in the background, an unreachable collector doesn't stop the server
+		otlpExporter, err := otlptracegrpc.New(context.Background(), options...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error on creating the otlp exporter: %w", err)
+		}
+
+		exporter = otlpExporter
+	}
+
+	tracerProvider, err := tracing.NewTracerProvider(serviceName, exporter, tracingConfig.SampleRatio)
+	if err != nil {
+		closeExporter()
+		return nil, nil, err
+	}
+
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), closeExporter())
+	}
+
+	return tracerProvider, shutdown, nil
+}
@@ -0,0 +1,156 @@
+// Package healthcheck drives the serving status of the standard grpc.health.v1 service from background probes
+package healthcheck
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// A dependency check, i.e. a database ping. The name identifies the probe in the logs and
+// a probe shared by many services only runs once per round.
+type Probe struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// The monitor periodically runs the probes of every registered service and reports each service
+// as SERVING only when all of its probes pass. The overall status (empty service name) is SERVING
+// only when every service is.
+type Monitor struct {
+	server   *health.Server
+	interval time.Duration
+	timeout  time.Duration
+	services map[string][]Probe
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus // Last reported status, to log the changes only
+	started  bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func NewMonitor(server *health.Server, interval time.Duration, timeout time.Duration) *Monitor {
+	// The health server starts with an overall SERVING status, it isn't known until the first probes
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &Monitor{
+		server:   server,
+		interval: interval,
+		timeout:  timeout,
+		services: map[string][]Probe{},
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Registers a service and the probes it depends on, it must be called before Start.
+// A service without probes is SERVING as long as the server runs.
+func (m *Monitor) Register(service string, probes ...Probe) {
+	m.services[service] = probes
+	m.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Runs a first round of probes, so the statuses are known before serving, then keeps probing in the background
+func (m *Monitor) Start() {
+	m.check(context.Background())
+
+	m.started = true
+	go m.loop()
+}
+
+// Reports every service as NOT_SERVING for good and stops probing, called when the server starts shutting down.
+// It must not be called concurrently with Start.
+func (m *Monitor) Shutdown() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+
+		if m.started {
+			<-m.done
+		}
+
+		m.server.Shutdown()
+		log.Print("health: shutting down, every service is not serving")
+	})
+}
+
+func (m *Monitor) loop() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.check(context.Background())
+		}
+	}
+}
+
+// Runs every probe once and updates the statuses of the services
+func (m *Monitor) check(ctx context.Context) {
+	results := map[string]error{}
+	overall := healthpb.HealthCheckResponse_SERVING
+
+	for service, probes := range m.services {
+		status := healthpb.HealthCheckResponse_SERVING
+
+		for _, probe := range probes {
+			err, ok := results[probe.Name]
+			if !ok {
+				err = m.runProbe(ctx, probe)
+				results[probe.Name] = err
+			}
+
+			if err != nil {
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+
+		if status != healthpb.HealthCheckResponse_SERVING {
+			overall = status
+		}
+
+		m.setStatus(service, status, probes, results)
+	}
+
+	m.setStatus("", overall, nil, results)
+}
+
+func (m *Monitor) runProbe(ctx context.Context, probe Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	return probe.Check(ctx)
+}
+
+func (m *Monitor) setStatus(service string, status healthpb.HealthCheckResponse_ServingStatus, probes []Probe, results map[string]error) {
+	// Updates after Shutdown are ignored by the health server
+	m.server.SetServingStatus(service, status)
+
+	if previous, ok := m.statuses[service]; ok && previous == status {
+		return
+	}
+
+	m.statuses[service] = status
+
+	if status == healthpb.HealthCheckResponse_SERVING {
+		log.Printf("health: service %q is serving", service)
+		return
+	}
+
+	log.Printf("health: service %q is not serving", service)
+
+	for _, probe := range probes {
+		if err := results[probe.Name]; err != nil {
+			log.Printf("health: probe %s of service %q failed: %s", probe.Name, service, err.Error())
+		}
+	}
+}
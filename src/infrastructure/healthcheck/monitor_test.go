@@ -0,0 +1,149 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// A probe whose result is set by the test
+type fakeProbe struct {
+	err   error
+	calls int
+}
+
+func (p *fakeProbe) probe(name string) Probe {
+	return Probe{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			p.calls++
+			return p.err
+		},
+	}
+}
+
+func statusOf(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	response, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.Status
+}
+
+func Test_Monitor_StatusFollowsTheProbes(t *testing.T) {
+	server := health.NewServer()
+	monitor := NewMonitor(server, time.Hour, time.Second)
+
+	database := &fakeProbe{}
+	cache := &fakeProbe{}
+
+	monitor.Register("explore", database.probe("postgres"))
+	monitor.Register("users", database.probe("postgres"), cache.probe("cache"))
+	monitor.Register("static")
+
+	// Nothing is serving before the first probes
+	assert.Equal(t, statusOf(t, server, "explore"), healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, statusOf(t, server, ""), healthpb.HealthCheckResponse_NOT_SERVING)
+
+	testCases := []struct {
+		name            string
+		databaseErr     error
+		cacheErr        error
+		expectedExplore healthpb.HealthCheckResponse_ServingStatus
+		expectedUsers   healthpb.HealthCheckResponse_ServingStatus
+		expectedOverall healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:            "All the probes pass",
+			expectedExplore: healthpb.HealthCheckResponse_SERVING,
+			expectedUsers:   healthpb.HealthCheckResponse_SERVING,
+			expectedOverall: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:            "Only the services using the failing probe are not serving",
+			cacheErr:        errors.New("connection refused"),
+			expectedExplore: healthpb.HealthCheckResponse_SERVING,
+			expectedUsers:   healthpb.HealthCheckResponse_NOT_SERVING,
+			expectedOverall: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:            "A shared probe fails",
+			databaseErr:     errors.New("connection refused"),
+			expectedExplore: healthpb.HealthCheckResponse_NOT_SERVING,
+			expectedUsers:   healthpb.HealthCheckResponse_NOT_SERVING,
+			expectedOverall: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:            "The probes recover",
+			expectedExplore: healthpb.HealthCheckResponse_SERVING,
+			expectedUsers:   healthpb.HealthCheckResponse_SERVING,
+			expectedOverall: healthpb.HealthCheckResponse_SERVING,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database.err = testCase.databaseErr
+			database.calls = 0
+			cache.err = testCase.cacheErr
+
+			monitor.check(context.Background())
+
+			assert.Equal(t, statusOf(t, server, "explore"), testCase.expectedExplore)
+			assert.Equal(t, statusOf(t, server, "users"), testCase.expectedUsers)
+			assert.Equal(t, statusOf(t, server, "static"), healthpb.HealthCheckResponse_SERVING)
+			assert.Equal(t, statusOf(t, server, ""), testCase.expectedOverall)
+
+			// The shared probe runs once per round
+			assert.Equal(t, database.calls, 1)
+		})
+	}
+}
+
+func Test_Monitor_ProbeTimeout(t *testing.T) {
+	server := health.NewServer()
+	monitor := NewMonitor(server, time.Hour, 10*time.Millisecond)
+
+	monitor.Register("explore", Probe{
+		Name: "stuck",
+		Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	monitor.check(context.Background())
+
+	assert.Equal(t, statusOf(t, server, "explore"), healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func Test_Monitor_Shutdown(t *testing.T) {
+	server := health.NewServer()
+	monitor := NewMonitor(server, 10*time.Millisecond, time.Second)
+
+	database := &fakeProbe{}
+	monitor.Register("explore", database.probe("postgres"))
+
+	monitor.Start()
+
+	assert.Equal(t, statusOf(t, server, "explore"), healthpb.HealthCheckResponse_SERVING)
+
+	monitor.Shutdown()
+
+	assert.Equal(t, statusOf(t, server, "explore"), healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, statusOf(t, server, ""), healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// The probes are stopped and a passing probe can't bring the service back
+	monitor.check(context.Background())
+
+	assert.Equal(t, statusOf(t, server, "explore"), healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// Shutting down twice is harmless
+	monitor.Shutdown()
+}
@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/lokker96/grpc_project/domain/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Verifies a bearer token and returns the caller it belongs to
+type TokenVerifier interface {
+	Verify(rawToken string) (*auth.Principal, error)
+}
+
+// Authenticates the caller of a method and returns the context carrying its principal
+type Authenticator func(ctx context.Context, fullMethod string) (context.Context, error)
+
+// Authenticates the callers with the bearer token of the 'authorization' metadata.
+// The public methods, i.e. the health checks, are served without a principal.
+func BearerAuthenticator(verifier TokenVerifier, publicMethods ...string) Authenticator {
+	public := make(map[string]struct{}, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, fullMethod string) (context.Context, error) {
+		if _, ok := public[fullMethod]; ok {
+			return ctx, nil
+		}
+
+		values := metadata.ValueFromIncomingContext(ctx, "authorization")
+		if len(values) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "expected 1 bearer token in the authorization metadata")
+		}
+
+		scheme, rawToken, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "bearer") || rawToken == "" {
+			return nil, status.Error(codes.Unauthenticated, "expected a bearer token in the authorization metadata")
+		}
+
+		principal, err := verifier.Verify(rawToken)
+		if err != nil {
+			// The reason stays in the server logs, it would help to forge a token
+			slog.WarnContext(ctx, "authentication failed", slog.String("grpc.method", fullMethod),
+				slog.String("request_id", RequestIDFromContext(ctx)), slog.String("error", err.Error()))
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		return auth.NewContext(ctx, principal), nil
+	}
+}
+
+// Runs every call as an admin without checking anything, for local development only
+func InsecureAuthenticator() Authenticator {
+	principal := &auth.Principal{Subject: "insecure-development", Roles: []auth.Role{auth.RoleAdmin}}
+
+	return func(ctx context.Context, fullMethod string) (context.Context, error) {
+		return auth.NewContext(ctx, principal), nil
+	}
+}
+
+func UnaryAuthentication(authenticate Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		logCaller(ctx)
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuthentication(authenticate Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		logCaller(ctx)
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// The subject of the caller goes to the log of the call, the public methods have none
+func logCaller(ctx context.Context) {
+	if principal, ok := auth.FromContext(ctx); ok {
+		setLoggedCaller(ctx, principal.Subject)
+	}
+}
+
+// Server stream with a context replaced by an interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,193 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Fields of the call log filled by the inner interceptors, i.e. the caller once it is authenticated
+type callLog struct {
+	caller string
+}
+
+type callLogContextKey struct{}
+
+// Records the authenticated caller in the log of the call, if the call is logged
+func setLoggedCaller(ctx context.Context, caller string) {
+	if entry, ok := ctx.Value(callLogContextKey{}).(*callLog); ok {
+		entry.caller = caller
+	}
+}
+
+// The requests carrying user IDs implement some of these getters, they are generated from the protos.
+// The IDs are strings in the v1 API and numbers in the v2 one.
+type (
+	actorUserIDGetter       interface{ GetActorUserId() string }
+	recipientUserIDGetter   interface{ GetRecipientUserId() string }
+	userIDGetter            interface{ GetUserId() string }
+	actorUserIDGetterV2     interface{ GetActorUserId() int64 }
+	recipientUserIDGetterV2 interface{ GetRecipientUserId() int64 }
+	userIDGetterV2          interface{ GetUserId() int64 }
+)
+
+// Logs every unary call once it is complete with its method, duration, status code and user IDs.
+// Must run after UnaryRequestID so the request ID is logged.
+func UnaryLogging(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		entry := &callLog{}
+		startedAt := time.Now()
+
+		response, err := handler(context.WithValue(ctx, callLogContextKey{}, entry), req)
+
+		logCall(ctx, logger, info.FullMethod, "unary", req, entry, startedAt, err)
+
+		return response, err
+	}
+}
+
+// Logs every stream once it ends, the user IDs are the ones of the request of the server streams
+func StreamLogging(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		entry := &callLog{}
+		startedAt := time.Now()
+		ctx := stream.Context()
+
+		loggedStream := &requestLoggingStream{
+			ServerStream: stream,
+			ctx:          context.WithValue(ctx, callLogContextKey{}, entry),
+		}
+
+		err := handler(srv, loggedStream)
+
+		logCall(ctx, logger, info.FullMethod, "stream", loggedStream.firstRequest, entry, startedAt, err)
+
+		return err
+	}
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, fullMethod string, callType string, req any, entry *callLog, startedAt time.Time, err error) {
+	code := status.Code(err)
+	service, method := splitFullMethod(fullMethod)
+
+	attributes := []slog.Attr{
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.String("grpc.type", callType),
+		slog.String("grpc.code", code.String()),
+		slog.Float64("duration_ms", float64(time.Since(startedAt).Microseconds())/1000),
+		slog.String("request_id", RequestIDFromContext(ctx)),
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attributes = append(attributes, slog.String("trace_id", spanContext.TraceID().String()))
+	}
+
+	if entry.caller != "" {
+		attributes = append(attributes, slog.String("caller", entry.caller))
+	}
+
+	attributes = append(attributes, userIDAttributes(req)...)
+
+	if err != nil {
+		attributes = append(attributes, slog.String("error", status.Convert(err).Message()))
+	}
+
+	logger.LogAttrs(ctx, levelOf(fullMethod, code), "grpc call", attributes...)
+}
+
+func userIDAttributes(req any) []slog.Attr {
+	var attributes []slog.Attr
+
+	add := func(key string, value string) {
+		if value != "" {
+			attributes = append(attributes, slog.String(key, value))
+		}
+	}
+
+	switch r := req.(type) {
+	case actorUserIDGetter:
+		add("actor_user_id", r.GetActorUserId())
+	case actorUserIDGetterV2:
+		add("actor_user_id", formatUserID(r.GetActorUserId()))
+	}
+
+	switch r := req.(type) {
+	case recipientUserIDGetter:
+		add("recipient_user_id", r.GetRecipientUserId())
+	case recipientUserIDGetterV2:
+		add("recipient_user_id", formatUserID(r.GetRecipientUserId()))
+	}
+
+	switch r := req.(type) {
+	case userIDGetter:
+		add("user_id", r.GetUserId())
+	case userIDGetterV2:
+		add("user_id", formatUserID(r.GetUserId()))
+	}
+
+	return attributes
+}
+
+// The unset IDs of the v2 requests are 0, they are not logged like the empty ones of the v1 requests
+func formatUserID(userID int64) string {
+	if userID == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(userID, 10)
+}
+
+// The health checks only show up in debug, the errors of the server are errors and the ones of the callers warnings
+func levelOf(fullMethod string, code codes.Code) slog.Level {
+	switch code {
+	case codes.OK, codes.Canceled:
+		if strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return slog.LevelDebug
+		}
+
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// Splits "/explore.ExploreService/ListLikedYou" into the service and the method names
+func splitFullMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+
+	return service, method
+}
+
+// Server stream keeping its first request for the log, the request of a server stream holds its user IDs
+type requestLoggingStream struct {
+	grpc.ServerStream
+	ctx          context.Context
+	firstRequest any
+}
+
+func (s *requestLoggingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *requestLoggingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.firstRequest == nil {
+		s.firstRequest = m
+	}
+
+	return err
+}
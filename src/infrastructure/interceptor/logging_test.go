@@ -0,0 +1,214 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Logger writing JSON lines to the buffer, every level included
+func newTestLogger() (*slog.Logger, *bytes.Buffer) {
+	buffer := &bytes.Buffer{}
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})), buffer
+}
+
+func decodeLogLine(t *testing.T, buffer *bytes.Buffer) map[string]any {
+	line := map[string]any{}
+	if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+		t.Fatalf("expected 1 JSON log line, got %q: %v", buffer.String(), err)
+	}
+
+	return line
+}
+
+func Test_UnaryLogging(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		handlerErr    error
+		expectedLevel string
+		expectedCode  string
+	}{
+		{
+			name:          "Success",
+			method:        "/explore.ExploreService/PutDecision",
+			handlerErr:    nil,
+			expectedLevel: "INFO",
+			expectedCode:  "OK",
+		},
+		{
+			name:          "Error of the caller",
+			method:        "/explore.ExploreService/PutDecision",
+			handlerErr:    status.Error(codes.PermissionDenied, "not your data"),
+			expectedLevel: "WARN",
+			expectedCode:  "PermissionDenied",
+		},
+		{
+			name:          "Error of the server",
+			method:        "/explore.ExploreService/PutDecision",
+			handlerErr:    status.Error(codes.Unavailable, "database unavailable"),
+			expectedLevel: "ERROR",
+			expectedCode:  "Unavailable",
+		},
+		{
+			name:          "Health check",
+			method:        "/grpc.health.v1.Health/Check",
+			handlerErr:    nil,
+			expectedLevel: "DEBUG",
+			expectedCode:  "OK",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logger, buffer := newTestLogger()
+
+			logging := UnaryLogging(logger)
+			authentication := UnaryAuthentication(BearerAuthenticator(fakeVerifier{}))
+			info := &grpc.UnaryServerInfo{FullMethod: testCase.method}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				"authorization", "Bearer valid",
+				RequestIDKey, "request-1",
+			))
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				return nil, testCase.handlerErr
+			}
+
+			// The chain of the server: request ID, logging and authentication
+			_, err := UnaryRequestID()(ctx, &explore.PutDecisionRequest{ActorUserId: "1", RecipientUserId: "2"}, info,
+				func(ctx context.Context, req any) (any, error) {
+					return logging(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+						return authentication(ctx, req, info, handler)
+					})
+				})
+			assert.Equal(t, err, testCase.handlerErr)
+
+			line := decodeLogLine(t, buffer)
+
+			assert.Equal(t, line["level"], testCase.expectedLevel)
+			assert.Equal(t, line["grpc.code"], testCase.expectedCode)
+			assert.Equal(t, line["request_id"], "request-1")
+			assert.Equal(t, line["caller"], "1")
+			assert.Equal(t, line["actor_user_id"], "1")
+			assert.Equal(t, line["recipient_user_id"], "2")
+
+			if _, ok := line["duration_ms"].(float64); !ok {
+				t.Fatal("expected the duration of the call")
+			}
+		})
+	}
+}
+
+func Test_UnaryLogging_RejectedCallsAreLogged(t *testing.T) {
+	logger, buffer := newTestLogger()
+
+	logging := UnaryLogging(logger)
+	authentication := UnaryAuthentication(BearerAuthenticator(fakeVerifier{}))
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/ListMatches"}
+
+	_, err := logging(context.Background(), &explore.ListMatchesRequest{UserId: "3"}, info, func(ctx context.Context, req any) (any, error) {
+		return authentication(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			t.Fatal("the handler must not run")
+			return nil, nil
+		})
+	})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	line := decodeLogLine(t, buffer)
+
+	assert.Equal(t, line["grpc.service"], "explore.ExploreService")
+	assert.Equal(t, line["grpc.method"], "ListMatches")
+	assert.Equal(t, line["grpc.code"], "Unauthenticated")
+	assert.Equal(t, line["user_id"], "3")
+	assert.Equal(t, line["caller"], nil)
+}
+
+// Server stream receiving a single request, like the server streams of the explore service
+type requestStream struct {
+	fakeServerStream
+	request *explore.WatchLikesRequest
+}
+
+func (s *requestStream) RecvMsg(m any) error {
+	m.(*explore.WatchLikesRequest).RecipientUserId = s.request.RecipientUserId
+	return nil
+}
+
+func Test_StreamLogging(t *testing.T) {
+	logger, buffer := newTestLogger()
+
+	info := &grpc.StreamServerInfo{FullMethod: "/explore.ExploreService/WatchLikes", IsServerStream: true}
+	stream := &requestStream{
+		fakeServerStream: fakeServerStream{ctx: context.Background()},
+		request:          &explore.WatchLikesRequest{RecipientUserId: "1"},
+	}
+
+	err := StreamLogging(logger)(nil, stream, info, func(srv any, stream grpc.ServerStream) error {
+		request := &explore.WatchLikesRequest{}
+		if err := stream.RecvMsg(request); err != nil {
+			return err
+		}
+
+		return status.Error(codes.Unavailable, "server stopping")
+	})
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+
+	line := decodeLogLine(t, buffer)
+
+	assert.Equal(t, line["grpc.type"], "stream")
+	assert.Equal(t, line["grpc.code"], "Unavailable")
+	assert.Equal(t, line["recipient_user_id"], "1")
+}
+
+func Test_UserIDAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		request  any
+		expected []slog.Attr
+	}{
+		{
+			name:    "v1 request",
+			request: &explore.PutDecisionRequest{ActorUserId: "1", RecipientUserId: "2"},
+			expected: []slog.Attr{
+				slog.String("actor_user_id", "1"),
+				slog.String("recipient_user_id", "2"),
+			},
+		},
+		{
+			name:    "v2 request",
+			request: &explorev2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 2},
+			expected: []slog.Attr{
+				slog.String("actor_user_id", "1"),
+				slog.String("recipient_user_id", "2"),
+			},
+		},
+		{
+			name:     "v2 request without user id",
+			request:  &explorev2.ListMatchesRequest{},
+			expected: nil,
+		},
+		{
+			name:     "Request without user ids",
+			request:  &explore.CountLikedYouResponse{},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, userIDAttributes(testCase.request), testCase.expected)
+		})
+	}
+}
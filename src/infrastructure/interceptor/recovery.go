@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Turns a panic in a handler into an Internal error instead of killing the process.
+// The stack trace is logged, the caller only gets a generic message.
+func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoveredError(ctx, logger, info.FullMethod, recovered)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecovery(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoveredError(stream.Context(), logger, info.FullMethod, recovered)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+func recoveredError(ctx context.Context, logger *slog.Logger, fullMethod string, recovered any) error {
+	logger.LogAttrs(ctx, slog.LevelError, "panic in grpc handler",
+		slog.String("grpc.method", fullMethod),
+		slog.String("request_id", RequestIDFromContext(ctx)),
+		slog.Any("panic", recovered),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_UnaryRecovery(t *testing.T) {
+	logger, buffer := newTestLogger()
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/CountLikedYou"}
+
+	_, err := UnaryRecovery(logger)(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		var decisions []int
+		return decisions[3], nil // Index out of range
+	})
+
+	// The caller doesn't learn anything about the panic
+	assert.Equal(t, status.Code(err), codes.Internal)
+	assert.Equal(t, status.Convert(err).Message(), "internal error")
+
+	line := decodeLogLine(t, buffer)
+
+	assert.Equal(t, line["level"], "ERROR")
+	assert.Equal(t, line["grpc.method"], "/explore.ExploreService/CountLikedYou")
+
+	if !strings.Contains(line["panic"].(string), "index out of range") {
+		t.Fatalf("expected the panic in the logs, got %v", line["panic"])
+	}
+
+	if !strings.Contains(line["stack"].(string), "recovery_test.go") {
+		t.Fatal("expected the stack trace of the panic in the logs")
+	}
+}
+
+func Test_StreamRecovery(t *testing.T) {
+	logger, buffer := newTestLogger()
+	info := &grpc.StreamServerInfo{FullMethod: "/explore.ExploreService/WatchLikes", IsServerStream: true}
+
+	err := StreamRecovery(logger)(nil, &fakeServerStream{ctx: context.Background()}, info, func(srv any, stream grpc.ServerStream) error {
+		panic("subscription is nil")
+	})
+
+	assert.Equal(t, status.Code(err), codes.Internal)
+	assert.Equal(t, decodeLogLine(t, buffer)["panic"], "subscription is nil")
+}
@@ -0,0 +1,77 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/lokker96/grpc_project/domain/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata key of the request ID, sent back to the caller in the response headers
+const RequestIDKey = "x-request-id"
+
+const maxRequestIDLength = 128
+
+// Returns the request ID of the call, empty outside of a call
+func RequestIDFromContext(ctx context.Context) string {
+	return audit.RequestIDFromContext(ctx)
+}
+
+// Keeps the request ID sent by the caller, i.e. a gateway, or assigns a new one
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := incomingRequestID(ctx)
+
+		// The header is sent with the response, a failure only means the caller doesn't get it
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+
+		// The request ID is kept in the domain context so the changes of the call are recorded with it
+		return handler(audit.NewContext(ctx, requestID), req)
+	}
+}
+
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		requestID := incomingRequestID(stream.Context())
+
+		_ = stream.SetHeader(metadata.Pairs(RequestIDKey, requestID))
+
+		ctx := audit.NewContext(stream.Context(), requestID)
+
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// The request ID of the caller is only kept when it is safe to log as it is
+func incomingRequestID(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, RequestIDKey)
+	if len(values) == 1 && isValidRequestID(values[0]) {
+		return values[0]
+	}
+
+	return newRequestID()
+}
+
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id) // Never fails, see crypto/rand
+
+	return hex.EncodeToString(id)
+}
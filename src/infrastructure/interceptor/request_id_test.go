@@ -0,0 +1,59 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_UnaryRequestID(t *testing.T) {
+	interceptor := UnaryRequestID()
+
+	testCases := []struct {
+		name         string
+		values       []string
+		expectedKept bool // The request ID of the caller is kept
+	}{
+		{name: "Request ID of the caller", values: []string{"gateway-42"}, expectedKept: true},
+		{name: "No request ID", values: nil, expectedKept: false},
+		{name: "Many request IDs", values: []string{"a", "b"}, expectedKept: false},
+		{name: "Request ID with spaces", values: []string{"forged\nlog line"}, expectedKept: false},
+		{name: "Request ID too long", values: []string{strings.Repeat("a", 129)}, expectedKept: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			md := metadata.MD{}
+			for _, value := range testCase.values {
+				md.Append(RequestIDKey, value)
+			}
+
+			var requestID string
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				requestID = RequestIDFromContext(ctx)
+				return "response", nil
+			}
+
+			_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{}, handler)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.expectedKept {
+				assert.Equal(t, requestID, testCase.values[0])
+			} else {
+				// A new 128 bits ID
+				assert.Equal(t, len(requestID), 32)
+			}
+		})
+	}
+}
+
+func Test_RequestIDFromContext_OutsideOfACall(t *testing.T) {
+	assert.Equal(t, RequestIDFromContext(context.Background()), "")
+}
@@ -0,0 +1,24 @@
+// Package interceptor contains the gRPC interceptors shared by the services
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Gives the unary calls a deadline so a slow query can't hold a connection forever.
+// A shorter deadline sent by the client is kept, a timeout of 0 disables the interceptor.
+func UnaryTimeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Rejects the requests breaking the buf.validate rules of their message before the handler runs
+func UnaryValidation(validator protovalidate.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(ctx, validator, req, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Same for the streams, every message received from the client is validated
+func StreamValidation(validator protovalidate.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: stream, validator: validator, fullMethod: info.FullMethod})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	validator  protovalidate.Validator
+	fullMethod string
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validate(s.Context(), s.validator, m, s.fullMethod)
+}
+
+func validate(ctx context.Context, validator protovalidate.Validator, req any, fullMethod string) error {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := validator.Validate(message)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *protovalidate.ValidationError
+	if errors.As(err, &validationErr) {
+		return invalidArgument(validationErr)
+	}
+
+	// The rules of the message can't be compiled or evaluated, it is a bug of the proto and not of the client
+	slog.ErrorContext(ctx, "error validating the request", slog.String("grpc.method", fullMethod),
+		slog.String("request_id", RequestIDFromContext(ctx)), slog.String("error", err.Error()))
+
+	return status.Error(codes.Internal, "the request can't be validated")
+}
+
+// InvalidArgument status listing every violation in its message and in a BadRequest detail. The field is empty
+// for the rules of the whole message, i.e. the CEL rules comparing two fields.
+func invalidArgument(validationErr *protovalidate.ValidationError) error {
+	messages := make([]string, 0, len(validationErr.Violations))
+	fieldViolations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErr.Violations))
+
+	for _, violation := range validationErr.Violations {
+		field := protovalidate.FieldPathString(violation.Proto.GetField())
+
+		if field == "" {
+			messages = append(messages, violation.Proto.GetMessage())
+		} else {
+			messages = append(messages, field+": "+violation.Proto.GetMessage())
+		}
+
+		fieldViolations = append(fieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(messages, "; "))
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: fieldViolations})
+	if err != nil {
+		// The details can't be attached, the message alone still describes the violations
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,252 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fails like a proto with a rule that can't be compiled
+type brokenValidator struct{}
+
+func (brokenValidator) Validate(message proto.Message, options ...protovalidate.ValidationOption) error {
+	return &protovalidate.CompilationError{}
+}
+
+func newValidator(t *testing.T) protovalidate.Validator {
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return validator
+}
+
+func Test_UnaryValidation(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.v2.ExploreService/PutDecision"}
+
+	testCases := []struct {
+		name            string
+		validator       protovalidate.Validator
+		request         any
+		expectedCode    codes.Code
+		expectedHandled bool
+	}{
+		{
+			name:            "Valid request",
+			validator:       newValidator(t),
+			request:         &epv2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 2},
+			expectedCode:    codes.OK,
+			expectedHandled: true,
+		},
+		{
+			name:         "Invalid request",
+			validator:    newValidator(t),
+			request:      &epv2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 1},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Rules that can't be evaluated",
+			validator:    brokenValidator{},
+			request:      &epv2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 2},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handled := false
+
+			_, err := UnaryValidation(testCase.validator)(context.Background(), testCase.request, info, func(ctx context.Context, req any) (any, error) {
+				handled = true
+				return nil, nil
+			})
+
+			assert.Equal(t, status.Code(err), testCase.expectedCode)
+			assert.Equal(t, handled, testCase.expectedHandled)
+		})
+	}
+}
+
+// Server stream receiving a single message from the client
+type receivingServerStream struct {
+	grpc.ServerStream
+	received proto.Message
+}
+
+func (s *receivingServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *receivingServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.received)
+	return nil
+}
+
+func Test_StreamValidation(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/explore.v2.ExploreService/WatchLikes", IsServerStream: true}
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&epv2.WatchLikesRequest{})
+	}
+
+	err := StreamValidation(newValidator(t))(nil, &receivingServerStream{received: &epv2.WatchLikesRequest{RecipientUserId: 1}}, info, handler)
+	assert.Equal(t, err, nil)
+
+	err = StreamValidation(newValidator(t))(nil, &receivingServerStream{received: &epv2.WatchLikesRequest{RecipientUserId: -1}}, info, handler)
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+}
+
+func Test_UnaryValidation_Violations(t *testing.T) {
+	validator := newValidator(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.v2.ExploreService/PutDecision"}
+
+	longToken := strings.Repeat("a", 129)
+	validToken := "eyJ1IjoxLCJpIjoxfQ"
+
+	testCases := []struct {
+		name               string
+		request            proto.Message
+		expectedViolations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:    "Valid v2 request",
+			request: &epv2.ListLikedYouRequest{RecipientUserId: 1, PaginationToken: &validToken},
+		},
+		{
+			name:    "Missing v2 user id",
+			request: &epv2.ListLikedYouRequest{},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "recipient_user_id", Reason: "required", Description: "value is required"},
+			},
+		},
+		{
+			name:    "Negative v2 user id",
+			request: &epv2.CountLikedYouRequest{RecipientUserId: -1},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "recipient_user_id", Reason: "int64.gt", Description: "value must be greater than 0"},
+			},
+		},
+		{
+			name:    "Pagination token too long",
+			request: &epv2.ListMatchesRequest{UserId: 1, PaginationToken: &longToken},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "pagination_token", Reason: "string.max_len", Description: "value length must be at most 128 characters"},
+			},
+		},
+		{
+			name:    "Resume token too long",
+			request: &epv2.WatchLikesRequest{RecipientUserId: 1, ResumeToken: &longToken},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "resume_token", Reason: "string.max_len", Description: "value length must be at most 64 characters"},
+			},
+		},
+		{
+			name:    "Decision on yourself",
+			request: &epv2.PutDecisionRequest{ActorUserId: 1, RecipientUserId: 1, LikedRecipient: true},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Reason: "put_decision.different_users", Description: "actor_user_id and recipient_user_id must be different users"},
+			},
+		},
+		{
+			name:    "Every violation is reported",
+			request: &epv2.PutDecisionRequest{ActorUserId: -1},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "actor_user_id", Reason: "int64.gt", Description: "value must be greater than 0"},
+				{Field: "recipient_user_id", Reason: "required", Description: "value is required"},
+			},
+		},
+		{
+			name:    "Report without reason",
+			request: &epv2.ReportUserRequest{ActorUserId: 1, ReportedUserId: 2},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "reason", Reason: "required", Description: "value is required"},
+			},
+		},
+		{
+			name:    "Unknown liker order",
+			request: &epv2.ListLikedYouRequest{RecipientUserId: 1, Order: 42},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "order", Reason: "enum.defined_only", Description: "value must be one of the defined enum values"},
+			},
+		},
+		{
+			name:    "Block yourself",
+			request: &epv2.BlockUserRequest{ActorUserId: 3, BlockedUserId: 3},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Reason: "block_user.different_users", Description: "actor_user_id and blocked_user_id must be different users"},
+			},
+		},
+		{
+			name:    "Valid v1 request",
+			request: &ep.ListMatchesRequest{UserId: "9223372036854775807"},
+		},
+		{
+			name:    "Malformed v1 user id",
+			request: &ep.CountLikedYouRequest{RecipientUserId: "01"},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "recipient_user_id", Reason: "string.pattern", Description: "value does not match regex pattern `^[1-9][0-9]{0,18}$`"},
+			},
+		},
+		{
+			name:    "v1 decision on yourself",
+			request: &ep.PutDecisionRequest{ActorUserId: "2", RecipientUserId: "2"},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Reason: "put_decision.different_users", Description: "actor_user_id and recipient_user_id must be different users"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := UnaryValidation(validator)(context.Background(), testCase.request, info, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+
+			if testCase.expectedViolations == nil {
+				assert.Equal(t, err, nil)
+				return
+			}
+
+			st := status.Convert(err)
+			assert.Equal(t, st.Code(), codes.InvalidArgument)
+
+			if len(st.Details()) != 1 {
+				t.Fatal("expected the bad request details")
+			}
+
+			badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+			if !ok {
+				t.Fatal("expected the bad request details")
+			}
+
+			if len(badRequest.FieldViolations) != len(testCase.expectedViolations) {
+				t.Fatalf("expected %d violations, got %v", len(testCase.expectedViolations), badRequest.FieldViolations)
+			}
+
+			for i, expected := range testCase.expectedViolations {
+				assert.Equal(t, proto.Equal(badRequest.FieldViolations[i], expected), true, badRequest.FieldViolations[i].String())
+			}
+		})
+	}
+}
+
+func Test_UnaryValidation_StatusMessage(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.v2.ExploreService/PutDecision"}
+
+	_, err := UnaryValidation(newValidator(t))(context.Background(), &epv2.PutDecisionRequest{ActorUserId: -1, RecipientUserId: 2}, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+
+	assert.Equal(t, status.Convert(err).Message(), "invalid request: actor_user_id: value must be greater than 0")
+}
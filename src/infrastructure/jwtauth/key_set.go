@@ -0,0 +1,154 @@
+// Package jwtauth verifies the JWTs sent by the callers with the keys of a local JWKS file
+package jwtauth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Verification key of a JWKS file
+type key struct {
+	algorithm string // The only algorithm accepted with the key, so a RSA public key can't be used as a HMAC secret
+	material  any    // []byte for HS256, *rsa.PublicKey for RS256
+}
+
+// The keys of a JWKS file (RFC 7517) by key id. Only the symmetric HS256 ('oct') keys and
+// the RS256 public keys are supported.
+type KeySet struct {
+	keys map[string]key
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	K         string `json:"k"` // Secret of the 'oct' keys
+	N         string `json:"n"` // Modulus of the RSA keys
+	E         string `json:"e"` // Exponent of the RSA keys
+}
+
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading the jwks file: %w", err)
+	}
+
+	keySet, err := ParseKeySet(data)
+	if err != nil {
+		return nil, fmt.Errorf("error on parsing the jwks file %s: %w", path, err)
+	}
+
+	return keySet, nil
+}
+
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{keys: map[string]key{}}
+
+	for i, jwk := range document.Keys {
+		// Encryption keys are not meant to verify the tokens
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if jwk.KeyID == "" {
+			return nil, fmt.Errorf("key %d has no kid", i)
+		}
+
+		if _, ok := keySet.keys[jwk.KeyID]; ok {
+			return nil, fmt.Errorf("kid %s is used by more than 1 key", jwk.KeyID)
+		}
+
+		parsed, err := parseKey(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", jwk.KeyID, err)
+		}
+
+		keySet.keys[jwk.KeyID] = parsed
+	}
+
+	if len(keySet.keys) == 0 {
+		return nil, errors.New("no signing key found")
+	}
+
+	return keySet, nil
+}
+
+func parseKey(jwk jsonWebKey) (key, error) {
+	switch jwk.KeyType {
+	case "oct":
+		if jwk.Algorithm != "" && jwk.Algorithm != jwt.SigningMethodHS256.Alg() {
+			return key{}, fmt.Errorf("algorithm %s is not supported with an oct key", jwk.Algorithm)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil {
+			return key{}, errors.New("k is not base64url encoded")
+		}
+
+		// RFC 7518 requires a key of at least the size of the hash
+		if len(secret) < 32 {
+			return key{}, errors.New("HS256 secrets must be at least 32 bytes long")
+		}
+
+		return key{algorithm: jwt.SigningMethodHS256.Alg(), material: secret}, nil
+	case "RSA":
+		if jwk.Algorithm != "" && jwk.Algorithm != jwt.SigningMethodRS256.Alg() {
+			return key{}, fmt.Errorf("algorithm %s is not supported with a RSA key", jwk.Algorithm)
+		}
+
+		modulus, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(modulus) == 0 {
+			return key{}, errors.New("n is not base64url encoded")
+		}
+
+		exponent, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(exponent) == 0 || len(exponent) > 4 {
+			return key{}, errors.New("e is not a valid exponent")
+		}
+
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+
+		if publicKey.N.BitLen() < 2048 {
+			return key{}, errors.New("RSA keys must be at least 2048 bits long")
+		}
+
+		return key{algorithm: jwt.SigningMethodRS256.Alg(), material: publicKey}, nil
+	default:
+		return key{}, fmt.Errorf("key type %s is not supported", jwk.KeyType)
+	}
+}
+
+// Returns the key the token must be verified with, a jwt.Keyfunc
+func (k *KeySet) keyFor(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	verificationKey, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+
+	if token.Method.Alg() != verificationKey.algorithm {
+		return nil, fmt.Errorf("kid %q can't be used with %s", kid, token.Method.Alg())
+	}
+
+	return verificationKey.material, nil
+}
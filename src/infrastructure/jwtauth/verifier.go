@@ -0,0 +1,73 @@
+package jwtauth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+// Claims of the tokens: the subject is the user id for the users, the roles are optional
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []auth.Role `json:"roles,omitempty"`
+}
+
+type VerifierConfig struct {
+	Issuer   string        // Expected iss claim, not checked when empty
+	Audience string        // Expected aud claim, not checked when empty
+	Leeway   time.Duration // Clock skew allowed on exp, nbf and iat
+}
+
+// Verifies the signature and the claims of the tokens and turns them into principals
+type Verifier struct {
+	keySet *KeySet
+	parser *jwt.Parser
+}
+
+func NewVerifier(keySet *KeySet, config VerifierConfig) *Verifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(config.Leeway),
+	}
+
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &Verifier{
+		keySet: keySet,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+func (v *Verifier) Verify(rawToken string) (*auth.Principal, error) {
+	claims := &Claims{}
+
+	if _, err := v.parser.ParseWithClaims(rawToken, claims, v.keySet.keyFor); err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, jwt.ErrTokenRequiredClaimMissing
+	}
+
+	principal := &auth.Principal{
+		Subject: claims.Subject,
+		Roles:   claims.Roles,
+	}
+
+	// The subject of the services is not a user id
+	if userID, err := entity.ParseUserID(claims.Subject); err == nil {
+		principal.UserID = userID
+	}
+
+	return principal, nil
+}
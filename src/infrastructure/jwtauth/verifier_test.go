@@ -0,0 +1,202 @@
+package jwtauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/magiconair/properties/assert"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey
+}
+
+// Builds a JWKS document with the HS256 secret as "hmac" and the RSA public key as "rsa"
+func newKeySet(t *testing.T, rsaKey *rsa.PrivateKey) *KeySet {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	document := fmt.Sprintf(`{"keys": [
+		{"kty": "oct", "kid": "hmac", "alg": "HS256", "k": %q},
+		{"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig", "n": %q, "e": %q},
+		{"kty": "RSA", "kid": "encryption", "use": "enc", "n": "", "e": ""}
+	]}`,
+		encode(hmacSecret),
+		encode(rsaKey.N.Bytes()),
+		encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+	)
+
+	keySet, err := ParseKeySet([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keySet
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func validClaims(subject string) Claims {
+	now := time.Now()
+
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "https://auth.example.com",
+			Audience:  jwt.ClaimStrings{"explore"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+}
+
+func Test_Verifier(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	otherRSAKey := newRSAKey(t)
+
+	verifier := NewVerifier(newKeySet(t, rsaKey), VerifierConfig{
+		Issuer:   "https://auth.example.com",
+		Audience: "explore",
+	})
+
+	serviceClaims := validClaims("matching-service")
+	serviceClaims.Roles = []auth.Role{auth.RoleService}
+
+	expiredClaims := validClaims("1")
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	withoutExpirationClaims := validClaims("1")
+	withoutExpirationClaims.ExpiresAt = nil
+
+	otherIssuerClaims := validClaims("1")
+	otherIssuerClaims.Issuer = "https://evil.example.com"
+
+	otherAudienceClaims := validClaims("1")
+	otherAudienceClaims.Audience = jwt.ClaimStrings{"billing"}
+
+	testCases := []struct {
+		name              string
+		token             string
+		expectedPrincipal *auth.Principal
+	}{
+		{
+			name:              "HS256 user token",
+			token:             sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, validClaims("1")),
+			expectedPrincipal: &auth.Principal{Subject: "1", UserID: 1},
+		},
+		{
+			name:              "RS256 service token",
+			token:             sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, serviceClaims),
+			expectedPrincipal: &auth.Principal{Subject: "matching-service", Roles: []auth.Role{auth.RoleService}},
+		},
+		{
+			name:  "Signed with another key",
+			token: sign(t, jwt.SigningMethodRS256, "rsa", otherRSAKey, validClaims("1")),
+		},
+		{
+			name:  "Unknown kid",
+			token: sign(t, jwt.SigningMethodHS256, "unknown", hmacSecret, validClaims("1")),
+		},
+		{
+			name:  "Encryption keys can't verify tokens",
+			token: sign(t, jwt.SigningMethodRS256, "encryption", rsaKey, validClaims("1")),
+		},
+		{
+			// The RSA public key is public, it must not be usable as a HMAC secret
+			name:  "Algorithm confusion",
+			token: sign(t, jwt.SigningMethodHS256, "rsa", rsaKey.N.Bytes(), validClaims("1")),
+		},
+		{
+			name:  "Unsigned token",
+			token: sign(t, jwt.SigningMethodNone, "hmac", jwt.UnsafeAllowNoneSignatureType, validClaims("1")),
+		},
+		{
+			name:  "Expired token",
+			token: sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, expiredClaims),
+		},
+		{
+			name:  "Token without expiration",
+			token: sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, withoutExpirationClaims),
+		},
+		{
+			name:  "Token without subject",
+			token: sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, validClaims("")),
+		},
+		{
+			name:  "Other issuer",
+			token: sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, otherIssuerClaims),
+		},
+		{
+			name:  "Other audience",
+			token: sign(t, jwt.SigningMethodHS256, "hmac", hmacSecret, otherAudienceClaims),
+		},
+		{
+			name:  "Not a token",
+			token: "not a token",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			principal, err := verifier.Verify(testCase.token)
+
+			if testCase.expectedPrincipal == nil {
+				if err == nil {
+					t.Fatal("expected the token to be rejected")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, principal, testCase.expectedPrincipal)
+		})
+	}
+}
+
+func Test_ParseKeySet_Errors(t *testing.T) {
+	testCases := map[string]string{
+		"Not JSON":          `keys`,
+		"No key":            `{"keys": []}`,
+		"Missing kid":       `{"keys": [{"kty": "oct", "k": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"}]}`,
+		"Short HMAC secret": `{"keys": [{"kty": "oct", "kid": "hmac", "k": "c2hvcnQ"}]}`,
+		"Unsupported type":  `{"keys": [{"kty": "EC", "kid": "ec"}]}`,
+		"Unsupported alg":   `{"keys": [{"kty": "oct", "kid": "hmac", "alg": "HS512", "k": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"}]}`,
+		"Duplicated kid": `{"keys": [
+			{"kty": "oct", "kid": "hmac", "k": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"},
+			{"kty": "oct", "kid": "hmac", "k": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"}
+		]}`,
+	}
+
+	for name, document := range testCases {
+		if _, err := ParseKeySet([]byte(document)); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
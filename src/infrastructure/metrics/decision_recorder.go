@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Business counters of the decisions, implements event.DecisionRecorder
+type DecisionRecorder struct {
+	likes      prometheus.Counter
+	superLikes prometheus.Counter
+	passes     prometheus.Counter
+	matches    prometheus.Counter
+}
+
+func NewDecisionRecorder(registerer prometheus.Registerer) *DecisionRecorder {
+	r := &DecisionRecorder{
+		likes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "likes_created_total",
+			Help:      "Number of likes created, super likes excluded. Liking the same user again isn't counted.",
+		}),
+		superLikes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "super_likes_created_total",
+			Help:      "Number of super likes created, a like turned into a super like is counted too.",
+		}),
+		passes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "passes_created_total",
+			Help:      "Number of passes created, passing on the same user again isn't counted.",
+		}),
+		matches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "matches_created_total",
+			Help:      "Number of matches created.",
+		}),
+	}
+
+	registerer.MustRegister(r.likes, r.superLikes, r.passes, r.matches)
+
+	return r
+}
+
+func (r *DecisionRecorder) RecordDecision(decisionType entity.DecisionType, matched bool) {
+	if !decisionType.IsLike() {
+		r.passes.Inc()
+		return
+	}
+
+	if decisionType == entity.DecisionTypeSuperLike {
+		r.superLikes.Inc()
+	} else {
+		r.likes.Inc()
+	}
+
+	if matched {
+		r.matches.Inc()
+	}
+}
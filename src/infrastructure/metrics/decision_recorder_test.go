@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_DecisionRecorder(t *testing.T) {
+	decisionRecorder := NewDecisionRecorder(prometheus.NewRegistry())
+
+	decisionRecorder.RecordDecision(entity.DecisionTypeLike, false)
+	decisionRecorder.RecordDecision(entity.DecisionTypeLike, true)
+	decisionRecorder.RecordDecision(entity.DecisionTypeSuperLike, false)
+	decisionRecorder.RecordDecision(entity.DecisionTypePass, false)
+
+	// A match is a like too
+	assert.Equal(t, testutil.ToFloat64(decisionRecorder.likes), float64(2))
+	assert.Equal(t, testutil.ToFloat64(decisionRecorder.superLikes), float64(1))
+	assert.Equal(t, testutil.ToFloat64(decisionRecorder.matches), float64(1))
+	assert.Equal(t, testutil.ToFloat64(decisionRecorder.passes), float64(1))
+}
@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Decorates an explorer repository to time every query, whatever the storage behind it
+type ExplorerRepository struct {
+	next     repository.ExplorerRepository
+	duration *prometheus.HistogramVec
+}
+
+func NewExplorerRepository(next repository.ExplorerRepository, registerer prometheus.Registerer) *ExplorerRepository {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "repository",
+		Name:      "query_duration_seconds",
+		Help:      "Time taken by the repository queries, by query and outcome (ok or error).",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query", "outcome"})
+
+	registerer.MustRegister(duration)
+
+	return &ExplorerRepository{
+		next:     next,
+		duration: duration,
+	}
+}
+
+// Records the duration of a query, called with defer so err holds the error returned by the query
+func (r *ExplorerRepository) observe(query string, startedAt time.Time, err *error) {
+	outcome := "ok"
+	if *err != nil {
+		outcome = "error"
+	}
+
+	r.duration.WithLabelValues(query, outcome).Observe(time.Since(startedAt).Seconds())
+}
+
+func (r *ExplorerRepository) CreateUser(ctx context.Context, user *entity.User) (err error) {
+	defer r.observe("CreateUser", time.Now(), &err)
+
+	return r.next.CreateUser(ctx, user)
+}
+
+func (r *ExplorerRepository) GetUser(ctx context.Context, userID entity.UserID) (_ *entity.User, err error) {
+	defer r.observe("GetUser", time.Now(), &err)
+
+	return r.next.GetUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) DeactivateUser(ctx context.Context, userID entity.UserID) (_ *entity.User, err error) {
+	defer r.observe("DeactivateUser", time.Now(), &err)
+
+	return r.next.DeactivateUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) DeleteUser(ctx context.Context, userID entity.UserID) (err error) {
+	defer r.observe("DeleteUser", time.Now(), &err)
+
+	return r.next.DeleteUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) (err error) {
+	defer r.observe("EraseUser", time.Now(), &err)
+
+	return r.next.EraseUser(ctx, userID, erasure)
+}
+
+func (r *ExplorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) (err error) {
+	defer r.observe("CreateDecision", time.Now(), &err)
+
+	return r.next.CreateDecision(ctx, decision)
+}
+
+func (r *ExplorerRepository) ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	defer r.observe("ListDecisionsForUserId", time.Now(), &err)
+
+	return r.next.ListDecisionsForUserId(ctx, userID, cursor, limit)
+}
+
+func (r *ExplorerRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	defer r.observe("ListLikersForRecipientId", time.Now(), &err)
+
+	return r.next.ListLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	defer r.observe("ListNewLikersForRecipientId", time.Now(), &err)
+
+	return r.next.ListNewLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (_ map[entity.DecisionType]int64, err error) {
+	defer r.observe("GetLikesCountsByProfileId", time.Now(), &err)
+
+	return r.next.GetLikesCountsByProfileId(ctx, profileID)
+}
+
+func (r *ExplorerRepository) UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (_ *entity.Decision, _ *entity.Decision, err error) {
+	defer r.observe("UpsertDecision", time.Now(), &err)
+
+	return r.next.UpsertDecision(ctx, authorID, recipientID, decisionType)
+}
+
+func (r *ExplorerRepository) UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (_ *entity.DecisionChange, _ bool, err error) {
+	defer r.observe("UndoLastDecision", time.Now(), &err)
+
+	return r.next.UndoLastDecision(ctx, authorID, since)
+}
+
+func (r *ExplorerRepository) ListDecisionEvents(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int) (_ []entity.DecisionEvent, err error) {
+	defer r.observe("ListDecisionEvents", time.Now(), &err)
+
+	return r.next.ListDecisionEvents(ctx, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) (_ []entity.DecisionLogEvent, err error) {
+	defer r.observe("ReadDecisionLog", time.Now(), &err)
+
+	return r.next.ReadDecisionLog(ctx, afterID, missingIDs, limit)
+}
+
+func (r *ExplorerRepository) ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) (_ []entity.OutboxEvent, err error) {
+	defer r.observe("ClaimOutboxEvents", time.Now(), &err)
+
+	return r.next.ClaimOutboxEvents(ctx, limit, claimDuration)
+}
+
+func (r *ExplorerRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) (err error) {
+	defer r.observe("DeleteOutboxEvent", time.Now(), &err)
+
+	return r.next.DeleteOutboxEvent(ctx, eventID)
+}
+
+func (r *ExplorerRepository) RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) (err error) {
+	defer r.observe("RetryOutboxEvent", time.Now(), &err)
+
+	return r.next.RetryOutboxEvent(ctx, eventID, nextAttemptAt, lastError)
+}
+
+func (r *ExplorerRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (_ bool, err error) {
+	defer r.observe("FindMutualLike", time.Now(), &err)
+
+	return r.next.FindMutualLike(ctx, userID, recipientUserID)
+}
+
+func (r *ExplorerRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) (_ []entity.Match, err error) {
+	defer r.observe("ListMatchesForUserId", time.Now(), &err)
+
+	return r.next.ListMatchesForUserId(ctx, userID, cursor, limit)
+}
+
+func (r *ExplorerRepository) BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) (err error) {
+	defer r.observe("BlockUser", time.Now(), &err)
+
+	return r.next.BlockUser(ctx, blockerID, blockedID)
+}
+
+func (r *ExplorerRepository) UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) (err error) {
+	defer r.observe("UnblockUser", time.Now(), &err)
+
+	return r.next.UnblockUser(ctx, blockerID, blockedID)
+}
+
+func (r *ExplorerRepository) IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (_ bool, err error) {
+	defer r.observe("IsBlocked", time.Now(), &err)
+
+	return r.next.IsBlocked(ctx, userID, otherUserID)
+}
+
+func (r *ExplorerRepository) CreateReport(ctx context.Context, report *entity.Report) (err error) {
+	defer r.observe("CreateReport", time.Now(), &err)
+
+	return r.next.CreateReport(ctx, report)
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	repository_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/repository"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ExplorerRepository_TimesEveryQuery(t *testing.T) {
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+	repositoryMock.On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).Once().Return(map[entity.DecisionType]int64{entity.DecisionTypeLike: 2}, nil)
+	repositoryMock.On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(2)).Once().Return(nil, errors.New("connection refused"))
+	repositoryMock.On("UpsertDecision", mock.Anything, entity.UserID(1), entity.UserID(2), entity.DecisionTypeLike).Once().Return(nil, &entity.Decision{Type: entity.DecisionTypeLike}, nil)
+
+	explorerRepository := NewExplorerRepository(repositoryMock, prometheus.NewRegistry())
+
+	// The results of the decorated repository are returned as they are
+	counts, err := explorerRepository.GetLikesCountsByProfileId(context.Background(), 1)
+	assert.Equal(t, counts[entity.DecisionTypeLike], int64(2))
+	assert.Equal(t, err, nil)
+
+	_, err = explorerRepository.GetLikesCountsByProfileId(context.Background(), 2)
+	assert.Equal(t, err.Error(), "connection refused")
+
+	previous, current, err := explorerRepository.UpsertDecision(context.Background(), 1, 2, entity.DecisionTypeLike)
+	assert.Equal(t, previous == nil, true)
+	assert.Equal(t, current.Type, entity.DecisionTypeLike)
+	assert.Equal(t, err, nil)
+
+	testCases := []struct {
+		query         string
+		outcome       string
+		expectedCount uint64
+	}{
+		{query: "GetLikesCountsByProfileId", outcome: "ok", expectedCount: 1},
+		{query: "GetLikesCountsByProfileId", outcome: "error", expectedCount: 1},
+		{query: "UpsertDecision", outcome: "ok", expectedCount: 1},
+	}
+
+	for _, testCase := range testCases {
+		observer := explorerRepository.duration.WithLabelValues(testCase.query, testCase.outcome)
+
+		assert.Equal(t, sampleCount(t, observer), testCase.expectedCount, testCase.query+" "+testCase.outcome)
+	}
+
+	assert.Equal(t, testutil.CollectAndCount(explorerRepository.duration), 3)
+
+	repositoryMock.AssertExpectations(t)
+}
+
+// Number of observations of a histogram
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	metric := &dto.Metric{}
+	if err := observer.(prometheus.Metric).Write(metric); err != nil {
+		t.Fatal(err)
+	}
+
+	return metric.GetHistogram().GetSampleCount()
+}
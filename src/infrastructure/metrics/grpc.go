@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	unaryType        = "unary"
+	serverStreamType = "server_stream"
+	clientStreamType = "client_stream"
+	bidiStreamType   = "bidi_stream"
+)
+
+// Counts the gRPC calls by method and status code and measures their latency. The names follow the
+// usual grpc_server_* metrics so the existing dashboards work as they are.
+type ServerMetrics struct {
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+}
+
+func NewServerMetrics(registerer prometheus.Registerer) *ServerMetrics {
+	m := &ServerMetrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Number of calls started on the server.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Number of calls completed on the server, by status code.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time taken by the server to complete the calls, for the streams this is how long they stayed open.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+	}
+
+	registerer.MustRegister(m.started, m.handled, m.handling)
+
+	return m
+}
+
+// Creates the series of every method of the server with a zero value, so the rates are right from the first call
+func (m *ServerMetrics) InitializeMetrics(server *grpc.Server) {
+	for serviceName, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			callType := typeOf(method.IsClientStream, method.IsServerStream)
+
+			m.started.WithLabelValues(callType, serviceName, method.Name)
+			m.handling.WithLabelValues(callType, serviceName, method.Name)
+
+			for code := codes.OK; code <= codes.Unauthenticated; code++ {
+				m.handled.WithLabelValues(callType, serviceName, method.Name, code.String())
+			}
+		}
+	}
+}
+
+func (m *ServerMetrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		done := m.start(unaryType, info.FullMethod)
+
+		response, err := handler(ctx, req)
+		done(err)
+
+		return response, err
+	}
+}
+
+func (m *ServerMetrics) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := m.start(typeOf(info.IsClientStream, info.IsServerStream), info.FullMethod)
+
+		err := handler(srv, stream)
+		done(err)
+
+		return err
+	}
+}
+
+// Records the start of a call, the returned function records its completion
+func (m *ServerMetrics) start(callType string, fullMethod string) func(err error) {
+	serviceName, methodName := splitMethodName(fullMethod)
+	startedAt := time.Now()
+
+	m.started.WithLabelValues(callType, serviceName, methodName).Inc()
+
+	return func(err error) {
+		m.handled.WithLabelValues(callType, serviceName, methodName, status.Code(err).String()).Inc()
+		m.handling.WithLabelValues(callType, serviceName, methodName).Observe(time.Since(startedAt).Seconds())
+	}
+}
+
+func typeOf(isClientStream bool, isServerStream bool) string {
+	switch {
+	case isClientStream && isServerStream:
+		return bidiStreamType
+	case isClientStream:
+		return clientStreamType
+	case isServerStream:
+		return serverStreamType
+	default:
+		return unaryType
+	}
+}
+
+// Splits "/explore.ExploreService/ListLikedYou" into the service and the method names
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+
+	return "unknown", fullMethod
+}
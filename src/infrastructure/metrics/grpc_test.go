@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_UnaryInterceptor(t *testing.T) {
+	serverMetrics := NewServerMetrics(prometheus.NewRegistry())
+	interceptor := serverMetrics.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/ListLikedYou"}
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{name: "OK", err: nil},
+		{name: "NotFound", err: status.Error(codes.NotFound, "not found")},
+		{name: "NotFound", err: status.Error(codes.NotFound, "not found")},
+		{name: "Unknown", err: io.EOF}, // Not a status error
+	}
+
+	for _, testCase := range testCases {
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return "response", testCase.err
+		})
+
+		assert.Equal(t, err, testCase.err)
+	}
+
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.started.WithLabelValues("unary", "explore.ExploreService", "ListLikedYou")), float64(4))
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.handled.WithLabelValues("unary", "explore.ExploreService", "ListLikedYou", "OK")), float64(1))
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.handled.WithLabelValues("unary", "explore.ExploreService", "ListLikedYou", "NotFound")), float64(2))
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.handled.WithLabelValues("unary", "explore.ExploreService", "ListLikedYou", "Unknown")), float64(1))
+	assert.Equal(t, testutil.CollectAndCount(serverMetrics.handling), 1)
+}
+
+func Test_StreamInterceptor(t *testing.T) {
+	serverMetrics := NewServerMetrics(prometheus.NewRegistry())
+	interceptor := serverMetrics.StreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/explore.ExploreService/WatchLikes", IsServerStream: true}
+
+	err := interceptor(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		return status.Error(codes.Unavailable, "server stopping")
+	})
+
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.handled.WithLabelValues("server_stream", "explore.ExploreService", "WatchLikes", "Unavailable")), float64(1))
+}
+
+func Test_InitializeMetrics(t *testing.T) {
+	serverMetrics := NewServerMetrics(prometheus.NewRegistry())
+
+	grpcServer := grpc.NewServer()
+	explore.RegisterExploreServiceServer(grpcServer, explore.UnimplementedExploreServiceServer{})
+
+	serverMetrics.InitializeMetrics(grpcServer)
+
+	// Every method has its series before the first call
+	methods := len(explore.ExploreService_ServiceDesc.Methods) + len(explore.ExploreService_ServiceDesc.Streams)
+
+	assert.Equal(t, testutil.CollectAndCount(serverMetrics.started), methods)
+	assert.Equal(t, testutil.CollectAndCount(serverMetrics.handled), methods*int(codes.Unauthenticated+1))
+	assert.Equal(t, testutil.ToFloat64(serverMetrics.started.WithLabelValues("server_stream", "explore.ExploreService", "WatchLikes")), float64(0))
+}
+
+func Test_AdminServer(t *testing.T) {
+	registry := NewRegistry()
+	NewDecisionRecorder(registry).RecordDecision(entity.DecisionTypeLike, true)
+
+	server := httptest.NewServer(NewAdminServer(":0", registry).Handler)
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"explore_matches_created_total 1", "go_goroutines"} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("expected the metrics to contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/magiconair/properties/assert"
+)
+
+func publishToFile(t *testing.T, path string, messages ...event.DecisionMessage) {
+	publisher, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range messages {
+		if err := publisher.Publish(context.Background(), message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := publisher.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_FilePublisher_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	occurredAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	like := event.DecisionMessage{ID: 1, Type: "decision_made", PairKey: "1:2", AuthorID: 1, RecipientID: 2, DecisionType: "like", OccurredAt: occurredAt}
+	match := event.DecisionMessage{ID: 2, Type: "match_created", PairKey: "1:2", AuthorID: 2, RecipientID: 1, OccurredAt: occurredAt}
+
+	// The messages of the previous runs are kept
+	publishToFile(t, path, like)
+	publishToFile(t, path, match)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	messages := make([]event.DecisionMessage, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message event.DecisionMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatal(err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, messages, []event.DecisionMessage{like, match})
+}
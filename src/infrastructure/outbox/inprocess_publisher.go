@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/lokker96/grpc_project/domain/event"
+)
+
+// Handles a message published in process, an error makes the relay publish the message again later
+type Handler func(ctx context.Context, message event.DecisionMessage) error
+
+// Publishes the messages to the handlers subscribed in the same process, one after the other. Without any handler
+// the messages are dropped, which keeps the outbox empty when nothing consumes it.
+type InProcessPublisher struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{}
+}
+
+// Adds a handler, called for every message published from now on
+func (p *InProcessPublisher) Subscribe(handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+}
+
+// Calls every handler, even when one fails, so a message published again may be handled twice by some handlers
+func (p *InProcessPublisher) Publish(ctx context.Context, message event.DecisionMessage) error {
+	p.mu.RLock()
+	handlers := p.handlers
+	p.mu.RUnlock()
+
+	var errs []error
+
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// Publishes the messages as JSON on NATS, on the subject <prefix>.<type> (i.e. explore.decisions.match_created).
+// The ID of the message is in the Nats-Msg-Id header, so a JetStream stream on the subjects dedupes the messages
+// published again. A message is only reported as published once the server has acknowledged the flush.
+type NATSPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+func NewNATSPublisher(natsURL string, subjectPrefix string) (*NATSPublisher, error) {
+	// The connection is restored in the background, the publishes fail in the meantime and are retried by the relay
+	conn, err := nats.Connect(natsURL, nats.Name("explore-server"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("error on connecting to nats: %w", err)
+	}
+
+	return &NATSPublisher{
+		conn:          conn,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, message event.DecisionMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.subjectPrefix + "." + message.Type)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(message.ID, 10))
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("error on publishing to nats: %w", err)
+	}
+
+	// The flush needs a deadline, the relay sets one, the default timeout of the client is used otherwise
+	flush := p.conn.Flush
+	if _, ok := ctx.Deadline(); ok {
+		flush = func() error { return p.conn.FlushWithContext(ctx) }
+	}
+
+	if err := flush(); err != nil {
+		return fmt.Errorf("error on flushing to nats: %w", err)
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+
+	return nil
+}
+
+// Starts a NATS server in the process, listening on the address of the URL, for the local development without a broker
+func StartEmbeddedNATSServer(natsURL string) (*server.Server, error) {
+	parsedURL, err := url.Parse(natsURL)
+	if err != nil {
+		return nil, fmt.Errorf("error on parsing the nats url: %w", err)
+	}
+
+	port, err := strconv.Atoi(parsedURL.Port())
+	if err != nil {
+		return nil, fmt.Errorf("nats url %q must have a port: %w", natsURL, err)
+	}
+
+	natsServer, err := server.NewServer(&server.Options{
+		Host:   parsedURL.Hostname(),
+		Port:   port,
+		NoSigs: true, // The signals are handled by the explore server
+		NoLog:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on creating the embedded nats server: %w", err)
+	}
+
+	go natsServer.Start()
+
+	if !natsServer.ReadyForConnections(5 * time.Second) {
+		natsServer.Shutdown()
+		return nil, fmt.Errorf("embedded nats server isn't ready, is %s free?", parsedURL.Host)
+	}
+
+	return natsServer, nil
+}
@@ -0,0 +1,89 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/magiconair/properties/assert"
+	"github.com/nats-io/nats.go"
+)
+
+// Returns the URL of a free local port
+func freeNATSURL(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := listener.Addr().String()
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return "nats://" + address
+}
+
+func Test_NATSPublisher_PublishesOnTheTypeSubject(t *testing.T) {
+	natsURL := freeNATSURL(t)
+
+	natsServer, err := StartEmbeddedNATSServer(natsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer natsServer.Shutdown()
+
+	subscriber, err := nats.Connect(natsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscriber.Close()
+
+	subscription, err := subscriber.SubscribeSync("explore.decisions.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := subscriber.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	publisher, err := NewNATSPublisher(natsURL, "explore.decisions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	message := event.DecisionMessage{
+		ID:          7,
+		Type:        "match_created",
+		PairKey:     "1:2",
+		AuthorID:    2,
+		RecipientID: 1,
+		RequestID:   "request-1",
+		OccurredAt:  time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	if err := publisher.Publish(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := subscription.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The ID is the deduplication key of JetStream
+	assert.Equal(t, msg.Subject, "explore.decisions.match_created")
+	assert.Equal(t, msg.Header.Get(nats.MsgIdHdr), "7")
+
+	var received event.DecisionMessage
+	if err := json.Unmarshal(msg.Data, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, received, message)
+}
@@ -0,0 +1,175 @@
+// Package outbox relays the events of the transactional outbox to a publisher: in process, a JSON lines file or NATS
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+type RelayConfig struct {
+	BatchSize      int           // Events claimed at once
+	PollInterval   time.Duration // How often the outbox is checked when it has been found empty
+	ClaimDuration  time.Duration // How long a claimed event is reserved to the relay, far above the publish timeout
+	PublishTimeout time.Duration // Deadline of a single publish
+	MinBackoff     time.Duration // Delay before the first retry, doubled after every failed attempt
+	MaxBackoff     time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		BatchSize:      100,
+		PollInterval:   time.Second,
+		ClaimDuration:  time.Minute,
+		PublishTimeout: 10 * time.Second,
+		MinBackoff:     time.Second,
+		MaxBackoff:     5 * time.Minute,
+	}
+}
+
+// The relay publishes the events of the outbox and deletes them once published. A failed event is retried with an
+// exponential backoff, the next events of its pair wait for it so the consumers get the events of a pair in order.
+// The events of different pairs don't wait for each other, and many relays can run on the same outbox.
+type Relay struct {
+	repository repository.OutboxRepository
+	publisher  event.EventPublisher
+	config     RelayConfig
+	now        func() time.Time
+	started    bool
+	stopOnce   sync.Once
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+func NewRelay(outboxRepository repository.OutboxRepository, publisher event.EventPublisher, config RelayConfig) *Relay {
+	return &Relay{
+		repository: outboxRepository,
+		publisher:  publisher,
+		config:     config,
+		now:        time.Now,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Relays the events in the background until Stop
+func (r *Relay) Start() {
+	r.started = true
+	go r.loop()
+}
+
+// Waits for the batch being relayed, if any, and stops relaying. It must not be called concurrently with Start.
+func (r *Relay) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+
+		if r.started {
+			<-r.done
+		}
+	})
+}
+
+func (r *Relay) loop() {
+	defer close(r.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-timer.C:
+		}
+
+		// A round only claims the oldest event of each pair, the next ones become claimable once it is relayed.
+		// The rounds follow each other right away while they relay events, so the events of a busy pair don't
+		// wait for the poll interval one after the other.
+		relayed, err := r.RelayOnce(context.Background())
+		if err != nil {
+			log.Printf("outbox: relaying the events failed: %s", err.Error())
+		}
+
+		if relayed > 0 {
+			timer.Reset(0)
+		} else {
+			timer.Reset(r.config.PollInterval)
+		}
+	}
+}
+
+// Claims a batch of events and publishes them, returns how many have been published or scheduled for a retry.
+// An event whose outcome can't be recorded stays claimed until its claim expires, the rest of the batch goes on.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	outboxEvents, err := r.repository.ClaimOutboxEvents(ctx, r.config.BatchSize, r.config.ClaimDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	relayed := 0
+	var errs []error
+
+	// The claimed events are the oldest of their pairs, none of them has to wait for another
+	for _, outboxEvent := range outboxEvents {
+		if err := r.relay(ctx, outboxEvent); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", outboxEvent.ID, err))
+			continue
+		}
+
+		relayed++
+	}
+
+	return relayed, errors.Join(errs...)
+}
+
+// Publishes the event, the error is the one of the outbox, a failed publish is recorded for a retry
+func (r *Relay) relay(ctx context.Context, outboxEvent entity.OutboxEvent) error {
+	publishCtx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	publishErr := r.publisher.Publish(publishCtx, toMessage(outboxEvent))
+	cancel()
+
+	if publishErr == nil {
+		return r.repository.DeleteOutboxEvent(ctx, outboxEvent.ID)
+	}
+
+	backoff := r.backoff(outboxEvent.Attempts)
+	log.Printf("outbox: publishing event %d failed (attempt %d), retrying in %s: %s", outboxEvent.ID, outboxEvent.Attempts+1, backoff, publishErr.Error())
+
+	return r.repository.RetryOutboxEvent(ctx, outboxEvent.ID, r.now().Add(backoff), publishErr.Error())
+}
+
+// Delay before the next attempt after the given number of failed attempts, the current one excluded
+func (r *Relay) backoff(attempts int) time.Duration {
+	backoff := r.config.MinBackoff
+
+	for i := 0; i < attempts && backoff < r.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, r.config.MaxBackoff)
+}
+
+func toMessage(outboxEvent entity.OutboxEvent) event.DecisionMessage {
+	message := event.DecisionMessage{
+		ID:          outboxEvent.ID,
+		Type:        string(outboxEvent.Type),
+		PairKey:     outboxEvent.PairKey,
+		AuthorID:    outboxEvent.AuthorID.Int64(),
+		RecipientID: outboxEvent.RecipientID.Int64(),
+		RequestID:   outboxEvent.RequestID,
+		OccurredAt:  outboxEvent.OccurredAt,
+	}
+
+	if outboxEvent.DecisionType != nil {
+		message.DecisionType = string(*outboxEvent.DecisionType)
+	}
+
+	return message
+}
@@ -0,0 +1,298 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/audit"
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/memory"
+	"github.com/magiconair/properties/assert"
+)
+
+func createUsers(t *testing.T, explorerRepository repository.ExplorerRepository, count int) []entity.UserID {
+	userIDs := make([]entity.UserID, 0, count)
+
+	for i := 0; i < count; i++ {
+		user := &entity.User{}
+		if err := explorerRepository.CreateUser(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs
+}
+
+func upsert(t *testing.T, ctx context.Context, explorerRepository repository.ExplorerRepository, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) {
+	if _, _, err := explorerRepository.UpsertDecision(ctx, authorID, recipientID, decisionType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Runs a relay round and fails if it didn't relay the expected number of events
+func relayOnce(t *testing.T, relay *Relay, want int) {
+	relayed, err := relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, relayed, want)
+}
+
+// Records the IDs of the messages handled
+type recorder struct {
+	mu       sync.Mutex
+	messages []event.DecisionMessage
+}
+
+func (r *recorder) handle(ctx context.Context, message event.DecisionMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, message)
+
+	return nil
+}
+
+func (r *recorder) ids() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]int64, 0, len(r.messages))
+	for _, message := range r.messages {
+		ids = append(ids, message.ID)
+	}
+
+	return ids
+}
+
+func Test_Relay_PublishesTheDecisionMessages(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := audit.NewContext(context.Background(), "request-1")
+	users := createUsers(t, explorerRepository, 2)
+
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypeSuperLike)
+	upsert(t, ctx, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+
+	published := &recorder{}
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(published.handle)
+
+	relay := NewRelay(explorerRepository, publisher, DefaultRelayConfig())
+
+	// The events of the pair are published one round after the other, then deleted
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 0)
+
+	messages := published.messages
+	assert.Equal(t, len(messages), 3)
+
+	assert.Equal(t, messages[0].Type, string(entity.DecisionLogEventDecisionMade))
+	assert.Equal(t, messages[0].PairKey, entity.PairKey(users[0], users[1]))
+	assert.Equal(t, messages[0].AuthorID, users[0].Int64())
+	assert.Equal(t, messages[0].RecipientID, users[1].Int64())
+	assert.Equal(t, messages[0].DecisionType, string(entity.DecisionTypeSuperLike))
+	assert.Equal(t, messages[0].RequestID, "request-1")
+
+	assert.Equal(t, messages[1].AuthorID, users[1].Int64())
+	assert.Equal(t, messages[1].DecisionType, string(entity.DecisionTypeLike))
+
+	// The match is made by the like back and doesn't hold a decision
+	assert.Equal(t, messages[2].Type, string(entity.DecisionLogEventMatchCreated))
+	assert.Equal(t, messages[2].AuthorID, users[1].Int64())
+	assert.Equal(t, messages[2].DecisionType, "")
+}
+
+func Test_Relay_RetriesFailedEventsInOrder(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 3)
+
+	// Events 1 and 2 are the pair of users[0] and users[1], events 3 and 4 the pair of users[2] and users[0]
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypePass)
+	upsert(t, ctx, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+	upsert(t, ctx, explorerRepository, users[2], users[0], entity.DecisionTypeSuperLike)
+
+	published := &recorder{}
+	attempts := 0
+
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, message event.DecisionMessage) error {
+		if message.ID == 1 {
+			attempts++
+
+			if attempts <= 2 {
+				return errors.New("unavailable")
+			}
+		}
+
+		return published.handle(ctx, message)
+	})
+
+	relay := NewRelay(explorerRepository, publisher, DefaultRelayConfig())
+
+	// The clock of the relay is late, the failed events are due again right away
+	relay.now = func() time.Time { return time.Now().Add(-time.Hour) }
+
+	// Event 1 fails twice, event 2 waits for it while the other pair goes on
+	relayOnce(t, relay, 2)
+	relayOnce(t, relay, 2)
+	assert.Equal(t, published.ids(), []int64{3, 4})
+
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 0)
+
+	assert.Equal(t, published.ids(), []int64{3, 4, 1, 2})
+	assert.Equal(t, attempts, 3)
+}
+
+func Test_Relay_FailedEventsWaitForTheBackoff(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 2)
+
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, message event.DecisionMessage) error {
+		return errors.New("unavailable")
+	})
+
+	relay := NewRelay(explorerRepository, publisher, DefaultRelayConfig())
+
+	relayOnce(t, relay, 1)
+	relayOnce(t, relay, 0)
+}
+
+func Test_Relay_Backoff(t *testing.T) {
+	config := DefaultRelayConfig()
+	config.MinBackoff = time.Second
+	config.MaxBackoff = time.Minute
+
+	relay := NewRelay(memory.NewExplorerRepository(), NewInProcessPublisher(), config)
+
+	assert.Equal(t, relay.backoff(0), time.Second)
+	assert.Equal(t, relay.backoff(1), 2*time.Second)
+	assert.Equal(t, relay.backoff(3), 8*time.Second)
+	assert.Equal(t, relay.backoff(6), time.Minute)
+	assert.Equal(t, relay.backoff(1000), time.Minute)
+}
+
+func Test_Relay_StartAndStop(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	users := createUsers(t, explorerRepository, 2)
+
+	published := make(chan event.DecisionMessage, 1)
+
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, message event.DecisionMessage) error {
+		published <- message
+		return nil
+	})
+
+	config := DefaultRelayConfig()
+	config.PollInterval = 10 * time.Millisecond
+
+	relay := NewRelay(explorerRepository, publisher, config)
+	relay.Start()
+	defer relay.Stop()
+
+	// The decision is made once the relay found the outbox empty
+	upsert(t, context.Background(), explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+
+	select {
+	case message := <-published:
+		assert.Equal(t, message.AuthorID, users[0].Int64())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the decision hasn't been published")
+	}
+
+	relay.Stop()
+
+	// Stopping twice is fine
+	relay.Stop()
+}
+
+func Test_Relay_DrainsAPairWithoutWaitingForThePollInterval(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 2)
+
+	// The like, the like back and the match are the 3 events of the same pair, claimable one after the other
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, ctx, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+
+	published := make(chan event.DecisionMessage, 3)
+
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, message event.DecisionMessage) error {
+		published <- message
+		return nil
+	})
+
+	config := DefaultRelayConfig()
+	config.PollInterval = time.Hour
+
+	relay := NewRelay(explorerRepository, publisher, config)
+	relay.Start()
+	defer relay.Stop()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-published:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d events of the pair have been published", i)
+		}
+	}
+}
+
+// Outbox failing to delete an event, as if the database went away after the publish
+type undeletableOutboxRepository struct {
+	repository.OutboxRepository
+	undeletable int64
+}
+
+func (r *undeletableOutboxRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) error {
+	if eventID == r.undeletable {
+		return errors.New("connection reset")
+	}
+
+	return r.OutboxRepository.DeleteOutboxEvent(ctx, eventID)
+}
+
+func Test_Relay_GoesOnAfterAnOutboxFailure(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 4)
+
+	// Events 1, 2 and 3 are the first decisions of three pairs
+	upsert(t, ctx, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, ctx, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+	upsert(t, ctx, explorerRepository, users[0], users[3], entity.DecisionTypeLike)
+
+	published := &recorder{}
+	publisher := NewInProcessPublisher()
+	publisher.Subscribe(published.handle)
+
+	relay := NewRelay(&undeletableOutboxRepository{OutboxRepository: explorerRepository, undeletable: 1}, publisher, DefaultRelayConfig())
+
+	// The events after the failed one are published and deleted anyway
+	relayed, err := relay.RelayOnce(ctx)
+	assert.Equal(t, relayed, 2)
+	assert.Equal(t, err.Error(), "event 1: connection reset")
+	assert.Equal(t, published.ids(), []int64{1, 2, 3})
+
+	// Event 1 stays claimed until its claim expires
+	relayOnce(t, relay, 0)
+}
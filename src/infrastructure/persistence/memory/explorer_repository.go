@@ -0,0 +1,848 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/audit"
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+// Key of a decision, there is only 1 decision per author and recipient pair
+type decisionKey struct {
+	authorID    entity.UserID
+	recipientID entity.UserID
+}
+
+// Key of a block, there is only 1 block per blocker and blocked pair
+type blockKey struct {
+	blockerID entity.UserID
+	blockedID entity.UserID
+}
+
+// The in-memory explorer repository keeps the users and the decisions in maps guarded by a mutex.
+// It follows the same rules as the postgres repository and is meant for local development and tests,
+// the data is lost when the process stops.
+type explorerRepository struct {
+	mu             sync.RWMutex
+	users          map[entity.UserID]entity.User
+	decisions      map[decisionKey]entity.Decision
+	history        []entity.DecisionChange   // In the order of the changes
+	events         []entity.DecisionEvent    // In the order of the changes
+	log            []entity.DecisionLogEvent // In the order of the changes
+	outbox         []entity.OutboxEvent      // In the order of the changes
+	blocks         map[blockKey]entity.Block
+	reports        []entity.Report
+	erasures       []entity.UserErasure
+	nextUserID     entity.UserID
+	nextDecisionID int64
+	nextChangeID   int64
+	nextEventID    int64
+	nextLogEventID int64
+	nextOutboxID   int64
+	nextReportID   int64
+}
+
+func NewExplorerRepository() repository.ExplorerRepository {
+	return &explorerRepository{
+		users:          map[entity.UserID]entity.User{},
+		decisions:      map[decisionKey]entity.Decision{},
+		blocks:         map[blockKey]entity.Block{},
+		nextUserID:     1,
+		nextDecisionID: 1,
+		nextChangeID:   1,
+		nextEventID:    1,
+		nextLogEventID: 1,
+		nextOutboxID:   1,
+		nextReportID:   1,
+	}
+}
+
+func (r *explorerRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	user.ID = r.nextUserID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	r.users[user.ID] = *user
+	r.nextUserID++
+
+	return nil
+}
+
+func (r *explorerRepository) GetUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.DeletedAt != nil {
+		return nil, domainError.NewUserNotFoundErr()
+	}
+
+	return &user, nil
+}
+
+func (r *explorerRepository) DeactivateUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.DeletedAt != nil {
+		return nil, domainError.NewUserNotFoundErr()
+	}
+
+	now := time.Now()
+
+	if user.DeactivatedAt == nil {
+		user.DeactivatedAt = &now
+
+		r.appendLogEvent(ctx, userLogEvent(entity.DecisionLogEventUserDeactivated, userID, userID, now))
+	}
+	user.UpdatedAt = now
+
+	r.users[userID] = user
+
+	return &user, nil
+}
+
+func (r *explorerRepository) DeleteUser(ctx context.Context, userID entity.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.DeletedAt != nil {
+		return domainError.NewUserNotFoundErr()
+	}
+
+	// The user stays in the map like the soft deleted row in postgres, the foreign keys of its decisions still match
+	now := time.Now()
+
+	user.DeletedAt = &now
+	user.UpdatedAt = now
+
+	r.users[userID] = user
+
+	r.appendLogEvent(ctx, userLogEvent(entity.DecisionLogEventUserDeleted, userID, userID, now))
+
+	return nil
+}
+
+func (r *explorerRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The deleted users can be erased too, they are still in the map
+	if _, ok := r.users[userID]; !ok {
+		return domainError.NewUserNotFoundErr()
+	}
+
+	var decisionsErased int64
+
+	for key := range r.decisions {
+		if key.authorID == userID || key.recipientID == userID {
+			delete(r.decisions, key)
+			decisionsErased++
+		}
+	}
+
+	for key := range r.blocks {
+		if key.blockerID == userID || key.blockedID == userID {
+			delete(r.blocks, key)
+		}
+	}
+
+	r.history = slices.DeleteFunc(r.history, func(change entity.DecisionChange) bool {
+		return change.AuthorID == userID || change.RecipientID == userID
+	})
+
+	r.events = slices.DeleteFunc(r.events, func(decisionEvent entity.DecisionEvent) bool {
+		return decisionEvent.AuthorID == userID || decisionEvent.RecipientID == userID
+	})
+
+	r.log = slices.DeleteFunc(r.log, func(logEvent entity.DecisionLogEvent) bool {
+		return logEvent.AuthorID == userID || logEvent.RecipientID == userID
+	})
+
+	r.outbox = slices.DeleteFunc(r.outbox, func(outboxEvent entity.OutboxEvent) bool {
+		return outboxEvent.AuthorID == userID || outboxEvent.RecipientID == userID
+	})
+
+	r.reports = slices.DeleteFunc(r.reports, func(report entity.Report) bool {
+		return report.ReporterID == userID || report.ReportedID == userID
+	})
+
+	delete(r.users, userID)
+
+	erasure.ID = int64(len(r.erasures)) + 1
+	erasure.DecisionsErased = decisionsErased
+	erasure.ErasedAt = time.Now()
+
+	r.erasures = append(r.erasures, *erasure)
+
+	return nil
+}
+
+func (r *explorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUsersExist(decision.AuthorID, decision.RecipientID); err != nil {
+		return err
+	}
+
+	key := decisionKey{authorID: decision.AuthorID, recipientID: decision.RecipientID}
+	if _, ok := r.decisions[key]; ok {
+		return domainError.NewDatabaseErr(errDuplicatedDecision)
+	}
+
+	now := time.Now()
+
+	decision.ID = r.nextDecisionID
+	decision.CreatedAt = now
+	decision.UpdatedAt = now
+
+	r.decisions[key] = withoutRelations(*decision)
+	r.nextDecisionID++
+
+	r.appendLogEvent(ctx, decisionLogEvent(entity.DecisionLogEventDecisionMade, *decision, now))
+
+	if decision.IsLike() && r.likes(decision.RecipientID, decision.AuthorID) {
+		r.appendLogEvent(ctx, matchLogEvent(decision.AuthorID, decision.RecipientID, now))
+	}
+
+	return nil
+}
+
+func (r *explorerRepository) ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	decisions := make([]entity.Decision, 0)
+
+	for key, decision := range r.decisions {
+		if key.authorID != userID && key.recipientID != userID {
+			continue
+		}
+
+		if cursor != nil && !isBefore(decision.UpdatedAt, decision.ID, cursor.UpdatedAt, cursor.ID) {
+			continue
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return sortAndLimit(decisions, limit), nil
+}
+
+func (r *explorerRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.pageOfLikers(recipientID, query, cursor, limit, false), nil
+}
+
+func (r *explorerRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.pageOfLikers(recipientID, query, cursor, limit, true), nil
+}
+
+func (r *explorerRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := map[entity.DecisionType]int64{}
+	for _, decision := range r.decisions {
+		if decision.RecipientID == profileID && decision.IsLike() && r.isActive(decision.AuthorID) && !r.isBlocked(decision.AuthorID, profileID) {
+			counts[decision.Type]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (r *explorerRepository) UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (*entity.Decision, *entity.Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUsersExist(authorID, recipientID); err != nil {
+		return nil, nil, err
+	}
+
+	key := decisionKey{authorID: authorID, recipientID: recipientID}
+	now := time.Now()
+
+	var previous *entity.Decision
+	current, ok := r.decisions[key]
+
+	if ok {
+		previousDecision := current
+		previous = &previousDecision
+	} else {
+		current = entity.Decision{
+			ID:          r.nextDecisionID,
+			AuthorID:    authorID,
+			RecipientID: recipientID,
+			CreatedAt:   now,
+		}
+		r.nextDecisionID++
+	}
+
+	current.Type = decisionType
+	current.UpdatedAt = now
+
+	r.decisions[key] = current
+
+	change := entity.DecisionChange{
+		ID:          r.nextChangeID,
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		Type:        current.Type,
+		ChangedAt:   current.UpdatedAt,
+	}
+	r.nextChangeID++
+
+	if previous != nil {
+		change.PreviousType = &previous.Type
+		change.PreviousUpdatedAt = &previous.UpdatedAt
+	}
+
+	r.history = append(r.history, change)
+
+	r.appendEvent(ctx, entity.DecisionEvent{
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		OldType:     change.PreviousType,
+		NewType:     &current.Type,
+		Cause:       entity.DecisionEventCausePut,
+		OccurredAt:  current.UpdatedAt,
+	})
+
+	logEventType := entity.DecisionLogEventDecisionChanged
+	if previous == nil {
+		logEventType = entity.DecisionLogEventDecisionMade
+	}
+
+	r.appendLogEvent(ctx, decisionLogEvent(logEventType, current, current.UpdatedAt))
+
+	// The blocks don't matter here, the readers of the log hide the matches of the blocked pairs
+	if change.IsNewLike() && r.likes(recipientID, authorID) {
+		r.appendLogEvent(ctx, matchLogEvent(authorID, recipientID, current.UpdatedAt))
+	}
+
+	return previous, &current, nil
+}
+
+func (r *explorerRepository) UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (*entity.DecisionChange, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if r.history[i].AuthorID == authorID && r.history[i].UndoneAt == nil {
+			last = i
+			break
+		}
+	}
+
+	if last == -1 {
+		return nil, false, domainError.NewDecisionNotFoundErr()
+	}
+
+	change := &r.history[last]
+
+	// Only the most recent change can be undone, an older one is never undone in its place
+	if change.ChangedAt.Before(since) {
+		return nil, false, domainError.NewUndoWindowExpiredErr()
+	}
+
+	matchedBefore := r.isMatch(change.AuthorID, change.RecipientID)
+
+	key := decisionKey{authorID: change.AuthorID, recipientID: change.RecipientID}
+	decision := r.decisions[key]
+	now := time.Now()
+
+	if change.PreviousType == nil {
+		delete(r.decisions, key)
+
+		r.appendLogEvent(ctx, removedDecisionLogEvent(decision, now))
+	} else {
+		decision.Type = *change.PreviousType
+		decision.UpdatedAt = *change.PreviousUpdatedAt
+		r.decisions[key] = decision
+
+		r.appendLogEvent(ctx, decisionLogEvent(entity.DecisionLogEventDecisionChanged, decision, now))
+
+		// Undoing a pass restores the like it replaced, and the match it had made
+		if decision.IsLike() && !change.Type.IsLike() && r.likes(change.RecipientID, change.AuthorID) {
+			r.appendLogEvent(ctx, matchLogEvent(change.AuthorID, change.RecipientID, now))
+		}
+	}
+
+	change.UndoneAt = &now
+
+	undone := *change
+
+	r.appendEvent(ctx, entity.DecisionEvent{
+		AuthorID:    undone.AuthorID,
+		RecipientID: undone.RecipientID,
+		OldType:     &undone.Type,
+		NewType:     undone.PreviousType,
+		Cause:       entity.DecisionEventCauseUndo,
+		OccurredAt:  now,
+	})
+
+	return &undone, matchedBefore && !r.isMatch(change.AuthorID, change.RecipientID), nil
+}
+
+func (r *explorerRepository) ListDecisionEvents(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int) ([]entity.DecisionEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]entity.DecisionEvent, 0)
+
+	// Most recent first, the events are in the order of the changes
+	for i := len(r.events) - 1; i >= 0 && len(result) < limit; i-- {
+		decisionEvent := r.events[i]
+
+		if cursor != nil && decisionEvent.ID >= cursor.ID {
+			continue
+		}
+
+		if !involves(decisionEvent, query.UserID) || (query.OtherUserID != 0 && !involves(decisionEvent, query.OtherUserID)) {
+			continue
+		}
+
+		result = append(result, decisionEvent)
+	}
+
+	return result, nil
+}
+
+// Appends the event to the decision log and copies the events of the decisions into the outbox, must be called
+// while holding the write lock
+func (r *explorerRepository) appendLogEvent(ctx context.Context, logEvent entity.DecisionLogEvent) {
+	logEvent.ID = r.nextLogEventID
+	r.nextLogEventID++
+
+	r.log = append(r.log, logEvent)
+
+	if logEvent.IsUserEvent() {
+		return
+	}
+
+	outboxEvent := entity.NewOutboxEvent(logEvent, audit.RequestIDFromContext(ctx))
+	outboxEvent.ID = r.nextOutboxID
+	r.nextOutboxID++
+
+	r.outbox = append(r.outbox, outboxEvent)
+}
+
+// Event of the decision log about the activity of a user or a block
+func userLogEvent(eventType entity.DecisionLogEventType, authorID entity.UserID, recipientID entity.UserID, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:        eventType,
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		OccurredAt:  occurredAt,
+	}
+}
+
+func (r *explorerRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) ([]entity.DecisionLogEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// The log is in the order of the IDs and every event is committed right away, a missing ID is an erased event
+	compareID := func(logEvent entity.DecisionLogEvent, id int64) int {
+		return cmp.Compare(logEvent.ID, id)
+	}
+
+	result := make([]entity.DecisionLogEvent, 0)
+
+	for _, missingID := range slices.Sorted(slices.Values(missingIDs)) {
+		if missingID > afterID {
+			break
+		}
+
+		if i, found := slices.BinarySearchFunc(r.log, missingID, compareID); found {
+			result = append(result, r.log[i])
+		}
+	}
+
+	from, _ := slices.BinarySearchFunc(r.log, afterID+1, compareID)
+	result = append(result, r.log[from:]...)
+
+	return result[:min(limit, len(result))], nil
+}
+
+// Claims the oldest due event of each pair, in ID order, like the postgres repository
+func (r *explorerRepository) ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) ([]entity.OutboxEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	claimedUntil := now.Add(claimDuration)
+
+	claimed := make([]entity.OutboxEvent, 0)
+	pairsSeen := map[string]bool{}
+
+	for i := range r.outbox {
+		if len(claimed) == limit {
+			break
+		}
+
+		outboxEvent := &r.outbox[i]
+
+		// Only the oldest event of a pair can be claimed, the next ones wait for it
+		if pairsSeen[outboxEvent.PairKey] {
+			continue
+		}
+
+		pairsSeen[outboxEvent.PairKey] = true
+
+		if outboxEvent.NextAttemptAt.After(now) || (outboxEvent.ClaimedUntil != nil && outboxEvent.ClaimedUntil.After(now)) {
+			continue
+		}
+
+		outboxEvent.ClaimedUntil = &claimedUntil
+		claimed = append(claimed, *outboxEvent)
+	}
+
+	return claimed, nil
+}
+
+func (r *explorerRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outbox = slices.DeleteFunc(r.outbox, func(outboxEvent entity.OutboxEvent) bool {
+		return outboxEvent.ID == eventID
+	})
+
+	return nil
+}
+
+func (r *explorerRepository) RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Deleted with an erased user in the meantime, there is nothing left to retry
+	i := slices.IndexFunc(r.outbox, func(outboxEvent entity.OutboxEvent) bool {
+		return outboxEvent.ID == eventID
+	})
+	if i < 0 {
+		return nil
+	}
+
+	r.outbox[i].Attempts++
+	r.outbox[i].NextAttemptAt = nextAttemptAt
+	r.outbox[i].ClaimedUntil = nil
+	r.outbox[i].LastError = lastError
+
+	return nil
+}
+
+// Appends the event of a change of a decision, must be called while holding the lock
+func (r *explorerRepository) appendEvent(ctx context.Context, decisionEvent entity.DecisionEvent) {
+	decisionEvent.ID = r.nextEventID
+	decisionEvent.RequestID = audit.RequestIDFromContext(ctx)
+	r.nextEventID++
+
+	r.events = append(r.events, decisionEvent)
+}
+
+func (r *explorerRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.isMatch(userID, recipientUserID), nil
+}
+
+func (r *explorerRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) ([]entity.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]entity.Match, 0)
+
+	for key, mine := range r.decisions {
+		if key.authorID != userID || !mine.IsLike() {
+			continue
+		}
+
+		theirs, ok := r.decisions[decisionKey{authorID: key.recipientID, recipientID: key.authorID}]
+		if !ok || !theirs.IsLike() || !r.isActive(theirs.AuthorID) || r.isBlocked(userID, theirs.AuthorID) {
+			continue
+		}
+
+		match := entity.Match{
+			UserID:    key.recipientID,
+			MatchedAt: latest(mine.UpdatedAt, theirs.UpdatedAt),
+		}
+
+		if cursor != nil && !isBefore(match.MatchedAt, match.UserID, cursor.MatchedAt, cursor.UserID) {
+			continue
+		}
+
+		matches = append(matches, match)
+	}
+
+	// Most recent match first, same order as the postgres repository
+	sort.Slice(matches, func(i, j int) bool {
+		return isBefore(matches[j].MatchedAt, matches[j].UserID, matches[i].MatchedAt, matches[i].UserID)
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+func (r *explorerRepository) BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUsersExist(blockerID, blockedID); err != nil {
+		return err
+	}
+
+	// The first block date is kept
+	key := blockKey{blockerID: blockerID, blockedID: blockedID}
+	if _, ok := r.blocks[key]; !ok {
+		now := time.Now()
+
+		r.blocks[key] = entity.Block{
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+			CreatedAt: now,
+		}
+
+		r.appendLogEvent(ctx, userLogEvent(entity.DecisionLogEventUserBlocked, blockerID, blockedID, now))
+	}
+
+	return nil
+}
+
+func (r *explorerRepository) UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := blockKey{blockerID: blockerID, blockedID: blockedID}
+	if _, ok := r.blocks[key]; ok {
+		delete(r.blocks, key)
+
+		r.appendLogEvent(ctx, userLogEvent(entity.DecisionLogEventUserUnblocked, blockerID, blockedID, time.Now()))
+	}
+
+	return nil
+}
+
+func (r *explorerRepository) IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.isBlocked(userID, otherUserID), nil
+}
+
+func (r *explorerRepository) CreateReport(ctx context.Context, report *entity.Report) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUsersExist(report.ReporterID, report.ReportedID); err != nil {
+		return err
+	}
+
+	report.ID = r.nextReportID
+	report.CreatedAt = time.Now()
+
+	r.reports = append(r.reports, *report)
+	r.nextReportID++
+
+	return nil
+}
+
+// Returns the liked decisions received by the recipient of the query types, in the query order, starting after
+// the cursor. When onlyNew is true the likers that have been liked back by the recipient are excluded.
+// Must be called while holding the read lock.
+func (r *explorerRepository) pageOfLikers(recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int, onlyNew bool) []entity.Decision {
+	likers := make([]entity.Decision, 0)
+
+	types := query.Types
+	if len(types) == 0 {
+		types = entity.LikeDecisionTypes
+	}
+
+	for _, decision := range r.decisions {
+		if decision.RecipientID != recipientID || !slices.Contains(types, decision.Type) {
+			continue
+		}
+
+		if !r.isActive(decision.AuthorID) || r.isBlocked(recipientID, decision.AuthorID) {
+			continue
+		}
+
+		if onlyNew && r.likes(recipientID, decision.AuthorID) {
+			continue
+		}
+
+		if cursor != nil && !isLikerBefore(query.Order, decision, cursor.Rank, cursor.UpdatedAt, cursor.ID) {
+			continue
+		}
+
+		likers = append(likers, decision)
+	}
+
+	// Same order as the postgres pagination
+	sort.Slice(likers, func(i, j int) bool {
+		return isLikerBefore(query.Order, likers[j], likers[i].Type.Rank(), likers[i].UpdatedAt, likers[i].ID)
+	})
+
+	if len(likers) > limit {
+		likers = likers[:limit]
+	}
+
+	return likers
+}
+
+// True if the author likes the recipient, must be called while holding the lock
+func (r *explorerRepository) likes(authorID entity.UserID, recipientID entity.UserID) bool {
+	decision, ok := r.decisions[decisionKey{authorID: authorID, recipientID: recipientID}]
+
+	return ok && decision.IsLike()
+}
+
+// True if the users like each other, are both active and none has blocked the other. Must be called while holding the lock.
+func (r *explorerRepository) isMatch(userID entity.UserID, otherUserID entity.UserID) bool {
+	return r.likes(userID, otherUserID) && r.likes(otherUserID, userID) &&
+		r.isActive(userID) && r.isActive(otherUserID) && !r.isBlocked(userID, otherUserID)
+}
+
+// True if any of the users has blocked the other, must be called while holding the lock
+func (r *explorerRepository) isBlocked(userID entity.UserID, otherUserID entity.UserID) bool {
+	_, blocked := r.blocks[blockKey{blockerID: userID, blockedID: otherUserID}]
+	_, blockedBack := r.blocks[blockKey{blockerID: otherUserID, blockedID: userID}]
+
+	return blocked || blockedBack
+}
+
+// False for the deactivated and deleted users, their likes are hidden. Must be called while holding the lock.
+func (r *explorerRepository) isActive(userID entity.UserID) bool {
+	user, ok := r.users[userID]
+
+	return ok && user.DeactivatedAt == nil && user.DeletedAt == nil
+}
+
+// Mimics the foreign keys of the decisions table, must be called while holding the lock
+func (r *explorerRepository) checkUsersExist(userIDs ...entity.UserID) error {
+	for _, userID := range userIDs {
+		if _, ok := r.users[userID]; !ok {
+			return domainError.NewUserNotFoundErr()
+		}
+	}
+
+	return nil
+}
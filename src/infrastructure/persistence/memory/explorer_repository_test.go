@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/domain/repository/repositorytest"
+	"github.com/magiconair/properties/assert"
+)
+
+func Test_ExplorerRepositoryConformance(t *testing.T) {
+	repositorytest.RunExplorerRepositoryTests(t, func(t *testing.T) repository.ExplorerRepository {
+		return NewExplorerRepository()
+	})
+}
+
+func Test_UpsertDecision_Concurrent(t *testing.T) {
+	explorerRepository := NewExplorerRepository()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := explorerRepository.CreateUser(ctx, &entity.User{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const parallelCalls = 50
+
+	var wg sync.WaitGroup
+	created := make(chan bool, parallelCalls)
+
+	for i := 0; i < parallelCalls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			previous, _, err := explorerRepository.UpsertDecision(ctx, 1, 2, entity.DecisionTypeLike)
+			if err != nil {
+				t.Error(err)
+			}
+
+			created <- previous == nil
+		}()
+	}
+
+	wg.Wait()
+	close(created)
+
+	// Only the first upsert creates the decision
+	createdCount := 0
+	for wasCreated := range created {
+		if wasCreated {
+			createdCount++
+		}
+	}
+
+	assert.Equal(t, createdCount, 1)
+}
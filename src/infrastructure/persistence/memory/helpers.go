@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+// Same failure as the unique index on (author_id, recipient_id) in postgres
+var errDuplicatedDecision = errors.New("duplicate decision for author and recipient")
+
+// Keyset comparison used by the pagination: (at, id) < (cursorAt, cursorID)
+func isBefore[ID ~int64](at time.Time, id ID, cursorAt time.Time, cursorID ID) bool {
+	return at.Before(cursorAt) || (at.Equal(cursorAt) && id < cursorID)
+}
+
+// Keyset comparison of the liker lists, the rank of the type comes first in the ranked order
+func isLikerBefore(order repository.LikersOrder, decision entity.Decision, cursorRank int, cursorAt time.Time, cursorID int64) bool {
+	if order == repository.LikersOrderRanked && decision.Type.Rank() != cursorRank {
+		return decision.Type.Rank() < cursorRank
+	}
+
+	return isBefore(decision.UpdatedAt, decision.ID, cursorAt, cursorID)
+}
+
+// Sorts the decisions like the postgres pagination, most recently updated first, and keeps the first limit ones
+func sortAndLimit(decisions []entity.Decision, limit int) []entity.Decision {
+	sort.Slice(decisions, func(i, j int) bool {
+		return isBefore(decisions[j].UpdatedAt, decisions[j].ID, decisions[i].UpdatedAt, decisions[i].ID)
+	})
+
+	if len(decisions) > limit {
+		decisions = decisions[:limit]
+	}
+
+	return decisions
+}
+
+func latest(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+// The relations are filled by gorm in postgres, they are never stored in memory
+func withoutRelations(decision entity.Decision) entity.Decision {
+	decision.Author = entity.User{}
+	decision.Recipient = entity.User{}
+
+	return decision
+}
+
+// True if the user authored or received the decision changed by the event
+func involves(decisionEvent entity.DecisionEvent, userID entity.UserID) bool {
+	return decisionEvent.AuthorID == userID || decisionEvent.RecipientID == userID
+}
+
+// Event of the decision log holding the decision as it is after a change
+func decisionLogEvent(eventType entity.DecisionLogEventType, decision entity.Decision, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:              eventType,
+		AuthorID:          decision.AuthorID,
+		RecipientID:       decision.RecipientID,
+		DecisionID:        decision.ID,
+		NewType:           &decision.Type,
+		DecisionUpdatedAt: &decision.UpdatedAt,
+		OccurredAt:        occurredAt,
+	}
+}
+
+// Event of the decision log of a decision removed by an undo
+func removedDecisionLogEvent(decision entity.Decision, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:        entity.DecisionLogEventDecisionChanged,
+		AuthorID:    decision.AuthorID,
+		RecipientID: decision.RecipientID,
+		DecisionID:  decision.ID,
+		OccurredAt:  occurredAt,
+	}
+}
+
+// Event of the decision log of the match made by the like of the author
+func matchLogEvent(authorID entity.UserID, recipientID entity.UserID, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:        entity.DecisionLogEventMatchCreated,
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		OccurredAt:  occurredAt,
+	}
+}
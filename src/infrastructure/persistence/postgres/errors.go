@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+)
+
+// Postgres error codes, https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	foreignKeyViolationCode  = "23503" // A foreign key doesn't match any row
+	connectionExceptionClass = "08"    // The connection failed or was lost
+	queryCanceledCode        = "57014" // The statement timeout was reached
+	adminShutdownCode        = "57P01" // The server is shutting down
+	crashShutdownCode        = "57P02" // The server crashed
+	cannotConnectNowCode     = "57P03" // The server is starting up
+)
+
+// Translates the errors returned by gorm into domain errors, the decisions reference the users
+// table so a foreign key violation means that one of the users doesn't exist.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+		return domainError.NewUserNotFoundErr()
+	}
+
+	if isUnavailable(err) {
+		return domainError.NewDatabaseUnavailableErr(err)
+	}
+
+	return domainError.NewDatabaseErr(err)
+}
+
+// Tells if the database couldn't be reached or didn't answer in time, the other failures are bugs of the queries
+func isUnavailable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case queryCanceledCode, adminShutdownCode, crashShutdownCode, cannotConnectNowCode:
+			return true
+		}
+
+		return strings.HasPrefix(pgErr.Code, connectionExceptionClass)
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		pgconn.Timeout(err) ||
+		errors.Is(err, driver.ErrBadConn)
+}
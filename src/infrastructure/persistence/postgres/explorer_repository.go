@@ -1,9 +1,13 @@
 package postgres
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/lokker96/grpc_project/domain/audit"
 	"github.com/lokker96/grpc_project/domain/entity"
 	domainError "github.com/lokker96/grpc_project/domain/error"
 	"github.com/lokker96/grpc_project/domain/repository"
@@ -11,6 +15,7 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // The explorer repository implements the method we can use to access data from the DB.
@@ -30,123 +35,911 @@ func (r *explorerRepository) CreateUser(ctx context.Context, user *entity.User)
 	// this is typically used in more complex repository methods to preserve data integrity
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.WithContext(ctx).Create(&user).Error; err != nil {
-			return fmt.Errorf("error on creating user in db: %w", err)
+			return fmt.Errorf("error on creating user in db: %w", translateError(err))
 		}
 
 		return nil
 	})
 }
 
-func (r *explorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.WithContext(ctx).Create(&decision).Error; err != nil {
-			return fmt.Errorf("error on creating decision in db: %w", err)
+func (r *explorerRepository) GetUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	var user entity.User
+
+	err := r.db.WithContext(ctx).
+		Where("id = ?", userID).
+		Where("deleted_at IS NULL").
+		Take(&user).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainError.NewUserNotFoundErr()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error searching for user id: %w", translateError(err))
+	}
+
+	return &user, nil
+}
+
+func (r *explorerRepository) DeactivateUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	var user *entity.User
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var users []entity.User
+		now := time.Now()
+
+		// UPDATE ... RETURNING *, only when the user isn't deactivated yet so the deactivation is logged once
+		result := tx.
+			Model(&users).
+			Clauses(clause.Returning{}).
+			Where("id = ?", userID).
+			Where("deleted_at IS NULL").
+			Where("deactivated_at IS NULL").
+			Updates(map[string]any{
+				"deactivated_at": now,
+			})
+
+		if result.Error != nil {
+			return fmt.Errorf("error deactivating user in db: %w", translateError(result.Error))
 		}
 
+		if len(users) == 1 {
+			user = &users[0]
+
+			return appendDecisionLogEvent(tx, userLogEvent(entity.DecisionLogEventUserDeactivated, userID, userID, now))
+		}
+
+		// The first deactivation date is kept when the user is already deactivated
+		var deactivated entity.User
+
+		err := tx.Where("id = ?", userID).Where("deleted_at IS NULL").Take(&deactivated).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainError.NewUserNotFoundErr()
+		}
+
+		if err != nil {
+			return fmt.Errorf("error searching for deactivated user: %w", translateError(err))
+		}
+
+		user = &deactivated
+
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
-func (r *explorerRepository) GetDecisionsForRecipientId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error) {
+func (r *explorerRepository) DeleteUser(ctx context.Context, userID entity.UserID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.
+			Model(&entity.User{}).
+			Where("id = ?", userID).
+			Where("deleted_at IS NULL").
+			Update("deleted_at", now)
+
+		if result.Error != nil {
+			return fmt.Errorf("error deleting user in db: %w", translateError(result.Error))
+		}
+
+		if result.RowsAffected == 0 {
+			return domainError.NewUserNotFoundErr()
+		}
+
+		return appendDecisionLogEvent(tx, userLogEvent(entity.DecisionLogEventUserDeleted, userID, userID, now))
+	})
+}
+
+func (r *explorerRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locks the user, deleted or not, until the end of the transaction: an upsert of a decision
+		// referencing it waits for the erasure and then fails on the foreign key
+		var user entity.User
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", userID).Take(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainError.NewUserNotFoundErr()
+		}
+
+		if err != nil {
+			return fmt.Errorf("error locking user: %w", translateError(err))
+		}
+
+		result := tx.Where("author_id = ? OR recipient_id = ?", userID, userID).Delete(&entity.Decision{})
+		if result.Error != nil {
+			return fmt.Errorf("error erasing decisions of user: %w", translateError(result.Error))
+		}
+
+		erasure.DecisionsErased = result.RowsAffected
+
+		if err := tx.Where("author_id = ? OR recipient_id = ?", userID, userID).Delete(&entity.DecisionChange{}).Error; err != nil {
+			return fmt.Errorf("error erasing decision history of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("author_id = ? OR recipient_id = ?", userID, userID).Delete(&entity.DecisionEvent{}).Error; err != nil {
+			return fmt.Errorf("error erasing decision events of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("author_id = ? OR recipient_id = ?", userID, userID).Delete(&entity.DecisionLogEvent{}).Error; err != nil {
+			return fmt.Errorf("error erasing decision log of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("author_id = ? OR recipient_id = ?", userID, userID).Delete(&entity.OutboxEvent{}).Error; err != nil {
+			return fmt.Errorf("error erasing outbox events of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("blocker_id = ? OR blocked_id = ?", userID, userID).Delete(&entity.Block{}).Error; err != nil {
+			return fmt.Errorf("error erasing blocks of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("reporter_id = ? OR reported_id = ?", userID, userID).Delete(&entity.Report{}).Error; err != nil {
+			return fmt.Errorf("error erasing reports of user: %w", translateError(err))
+		}
+
+		if err := tx.Where("id = ?", userID).Delete(&entity.User{}).Error; err != nil {
+			return fmt.Errorf("error erasing user: %w", translateError(err))
+		}
+
+		if err := tx.Create(erasure).Error; err != nil {
+			return fmt.Errorf("error recording user erasure: %w", translateError(err))
+		}
+
+		return nil
+	})
+}
+
+func (r *explorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockPair(tx, decision.AuthorID, decision.RecipientID); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&decision).Error; err != nil {
+			return fmt.Errorf("error on creating decision in db: %w", translateError(err))
+		}
+
+		if err := appendDecisionLogEvent(tx, decisionLogEvent(entity.DecisionLogEventDecisionMade, *decision, decision.CreatedAt)); err != nil {
+			return err
+		}
+
+		if !decision.IsLike() {
+			return nil
+		}
+
+		return appendMatchLogEventIfLikedBack(tx, decision.AuthorID, decision.RecipientID, decision.CreatedAt)
+	})
+}
+
+func (r *explorerRepository) ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
 	var result []entity.Decision
 
 	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
 
-	queryBuilder = queryBuilder.Where("recipient_id = ?", uint(userID))
+	queryBuilder = queryBuilder.Where("author_id = ? OR recipient_id = ?", userID, userID)
+	queryBuilder = paginateDecisions(queryBuilder, cursor, limit)
 
-	if liked != nil {
-		queryBuilder = queryBuilder.Where("liked = ?", *liked)
+	if err := queryBuilder.Find(&result).Error; err != nil {
+		return nil, fmt.Errorf("error searching for decisions of user id: %w", translateError(err))
 	}
 
+	return result, nil
+}
+
+func (r *explorerRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	var result []entity.Decision
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
+
+	queryBuilder = queryBuilder.Where("recipient_id = ?", recipientID)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", query.Types)
+	queryBuilder = withActiveAuthors(queryBuilder, "decisions.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "decisions.author_id", "decisions.recipient_id")
+	queryBuilder = paginateLikers(queryBuilder, query.Order, cursor, limit)
+
 	err := queryBuilder.Find(&result).Error
 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, domainError.NewDecisionNotFoundErr()
-		} else {
-			return nil, fmt.Errorf("error searching for decisions liked for user id: %w", err)
-		}
+		return nil, fmt.Errorf("error searching for likers of recipient id: %w", translateError(err))
 	}
 
 	return result, nil
 }
 
-func (r *explorerRepository) GetDecisionsForUserId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error) {
+func (r *explorerRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
 	var result []entity.Decision
 
 	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
 
-	queryBuilder = queryBuilder.Where("author_id = ?", uint(userID))
+	queryBuilder = queryBuilder.Where("recipient_id = ?", recipientID)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", query.Types)
+
+	// Exclude the likers that the recipient has already liked back
+	queryBuilder = queryBuilder.Where(
+		"NOT EXISTS (?)",
+		r.db.Table("decisions AS liked_back").
+			Select("1").
+			Where("liked_back.author_id = decisions.recipient_id").
+			Where("liked_back.recipient_id = decisions.author_id").
+			Where("liked_back.type IN ?", entity.LikeDecisionTypes),
+	)
+	queryBuilder = withActiveAuthors(queryBuilder, "decisions.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "decisions.author_id", "decisions.recipient_id")
+	queryBuilder = paginateLikers(queryBuilder, query.Order, cursor, limit)
 
-	if liked != nil {
-		queryBuilder = queryBuilder.Where("liked = ?", *liked)
+	err := queryBuilder.Find(&result).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("error searching for new likers of recipient id: %w", translateError(err))
 	}
 
-	err := queryBuilder.Find(&result).Error
+	return result, nil
+}
+
+// Leaves out the decisions made by the deactivated and deleted users, idx_users_inactive only holds those users
+func withActiveAuthors(queryBuilder *gorm.DB, authorColumn string) *gorm.DB {
+	return queryBuilder.Where(
+		"NOT EXISTS (SELECT 1 FROM users AS inactive WHERE inactive.id = " + authorColumn +
+			" AND (inactive.deactivated_at IS NOT NULL OR inactive.deleted_at IS NOT NULL))",
+	)
+}
+
+// Leaves out the decisions between 2 users when any of them has blocked the other.
+// The primary key of blocks and idx_blocks_blocked_blocker cover both directions.
+func withoutBlockedPairs(queryBuilder *gorm.DB, userColumn string, otherUserColumn string) *gorm.DB {
+	return queryBuilder.Where(
+		"NOT EXISTS (SELECT 1 FROM blocks WHERE (blocks.blocker_id = " + userColumn + " AND blocks.blocked_id = " + otherUserColumn +
+			") OR (blocks.blocker_id = " + otherUserColumn + " AND blocks.blocked_id = " + userColumn + "))",
+	)
+}
+
+// Keeps the decisions of the like types, every like type when none is given
+func withLikeTypes(queryBuilder *gorm.DB, typeColumn string, types []entity.DecisionType) *gorm.DB {
+	if len(types) == 0 {
+		types = entity.LikeDecisionTypes
+	}
+
+	return queryBuilder.Where(typeColumn+" IN ?", types)
+}
+
+// Rank of the decision type in SQL, it must be the same as entity.DecisionType.Rank
+const decisionRank = "CASE WHEN decisions.type = 'super_like' THEN 1 ELSE 0 END"
+
+// Applies the keyset pagination of the liker lists. In the ranked order the super likes come first,
+// the rank is part of the cursor so the pages don't overlap.
+func paginateLikers(queryBuilder *gorm.DB, order repository.LikersOrder, cursor *repository.DecisionCursor, limit int) *gorm.DB {
+	if order == repository.LikersOrderMostRecent {
+		return paginateDecisions(queryBuilder, cursor, limit)
+	}
+
+	if cursor != nil {
+		queryBuilder = queryBuilder.Where("("+decisionRank+", decisions.updated_at, decisions.id) < (?, ?, ?)", cursor.Rank, cursor.UpdatedAt, cursor.ID)
+	}
+
+	return queryBuilder.
+		Order(decisionRank + " DESC").
+		Order("decisions.updated_at DESC").
+		Order("decisions.id DESC").
+		Limit(limit)
+}
+
+// Applies the keyset pagination: most recently updated decisions first, starting after the cursor
+func paginateDecisions(queryBuilder *gorm.DB, cursor *repository.DecisionCursor, limit int) *gorm.DB {
+	if cursor != nil {
+		queryBuilder = queryBuilder.Where("(decisions.updated_at, decisions.id) < (?, ?)", cursor.UpdatedAt, cursor.ID)
+	}
+
+	return queryBuilder.
+		Order("decisions.updated_at DESC").
+		Order("decisions.id DESC").
+		Limit(limit)
+}
+
+func (r *explorerRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error) {
+	var rows []struct {
+		Type  entity.DecisionType
+		Count int64
+	}
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{}).Select("decisions.type, COUNT(*) AS count")
+
+	queryBuilder = queryBuilder.Where("recipient_id = ?", profileID)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", nil)
+	queryBuilder = withActiveAuthors(queryBuilder, "decisions.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "decisions.author_id", "decisions.recipient_id")
+
+	if err := queryBuilder.Group("decisions.type").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error counting likes for profile id: %w", translateError(err))
+	}
+
+	counts := make(map[entity.DecisionType]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *explorerRepository) UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (*entity.Decision, *entity.Decision, error) {
+	var previous *entity.Decision
+	var current *entity.Decision
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialise the decisions of the author until the end of the transaction so the previous
+		// state we read is exactly the one that gets overwritten, and the undo always sees the
+		// most recent change of the author
+		if err := lockAuthor(tx, authorID); err != nil {
+			return err
+		}
 
+		if err := lockPair(tx, authorID, recipientID); err != nil {
+			return err
+		}
+
+		var existing entity.Decision
+
+		err := tx.Where("author_id = ?", authorID).
+			Where("recipient_id = ?", recipientID).
+			Take(&existing).Error
+
+		if err == nil {
+			previous = &existing
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("error searching for previous decision: %w", translateError(err))
+		}
+
+		decision := entity.Decision{
+			AuthorID:    authorID,
+			RecipientID: recipientID,
+			Type:        decisionType,
+		}
+
+		// INSERT ... ON CONFLICT (author_id, recipient_id) DO UPDATE ... RETURNING *
+		// relies on the unique index so concurrent calls can never create duplicated rows
+		err = tx.Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "author_id"}, {Name: "recipient_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"type", "updated_at"}),
+			},
+			clause.Returning{},
+		).Create(&decision).Error
+		if err != nil {
+			return fmt.Errorf("error upserting decision in db: %w", translateError(err))
+		}
+
+		current = &decision
+
+		change := entity.DecisionChange{
+			AuthorID:    authorID,
+			RecipientID: recipientID,
+			Type:        current.Type,
+			ChangedAt:   current.UpdatedAt,
+		}
+
+		if previous != nil {
+			change.PreviousType = &previous.Type
+			change.PreviousUpdatedAt = &previous.UpdatedAt
+		}
+
+		if err := tx.Create(&change).Error; err != nil {
+			return fmt.Errorf("error recording decision change: %w", translateError(err))
+		}
+
+		err = appendDecisionEvent(ctx, tx, entity.DecisionEvent{
+			AuthorID:    authorID,
+			RecipientID: recipientID,
+			OldType:     change.PreviousType,
+			NewType:     &current.Type,
+			Cause:       entity.DecisionEventCausePut,
+			OccurredAt:  current.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		logEventType := entity.DecisionLogEventDecisionChanged
+		if previous == nil {
+			logEventType = entity.DecisionLogEventDecisionMade
+		}
+
+		if err := appendDecisionLogEvent(tx, decisionLogEvent(logEventType, *current, current.UpdatedAt)); err != nil {
+			return err
+		}
+
+		if !change.IsNewLike() {
+			return nil
+		}
+
+		return appendMatchLogEventIfLikedBack(tx, authorID, recipientID, current.UpdatedAt)
+	})
 	if err != nil {
+		return nil, nil, err
+	}
+
+	return previous, current, nil
+}
+
+func (r *explorerRepository) UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (*entity.DecisionChange, bool, error) {
+	var change entity.DecisionChange
+	var matchDissolved bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockAuthor(tx, authorID); err != nil {
+			return err
+		}
+
+		err := tx.Where("author_id = ?", authorID).
+			Where("undone_at IS NULL").
+			Order("id DESC").
+			Take(&change).Error
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, domainError.NewDecisionNotFoundErr()
+			return domainError.NewDecisionNotFoundErr()
+		}
+
+		if err != nil {
+			return fmt.Errorf("error searching for last decision change: %w", translateError(err))
+		}
+
+		// Only the most recent change can be undone, an older one is never undone in its place
+		if change.ChangedAt.Before(since) {
+			return domainError.NewUndoWindowExpiredErr()
+		}
+
+		if err := lockPair(tx, change.AuthorID, change.RecipientID); err != nil {
+			return err
+		}
+
+		matchedBefore, err := findMutualLike(tx, change.AuthorID, change.RecipientID)
+		if err != nil {
+			return err
+		}
+
+		var restored []entity.Decision
+
+		// DELETE or UPDATE ... RETURNING *, the decision log records the decision as it is after the undo
+		pair := tx.Clauses(clause.Returning{}).Where("author_id = ?", change.AuthorID).Where("recipient_id = ?", change.RecipientID)
+
+		if change.PreviousType == nil {
+			err = pair.Delete(&restored).Error
 		} else {
-			return nil, fmt.Errorf("error searching for decisions for user id: %w", err)
+			// UpdateColumns doesn't touch updated_at, the previous one is restored as it was
+			err = pair.Model(&restored).UpdateColumns(map[string]any{
+				"type":       *change.PreviousType,
+				"updated_at": *change.PreviousUpdatedAt,
+			}).Error
 		}
+
+		if err != nil {
+			return fmt.Errorf("error restoring decision: %w", translateError(err))
+		}
+
+		if len(restored) == 0 {
+			return domainError.NewDecisionNotFoundErr()
+		}
+
+		now := time.Now()
+		change.UndoneAt = &now
+
+		if change.PreviousType == nil {
+			err = appendDecisionLogEvent(tx, removedDecisionLogEvent(restored[0], now))
+		} else {
+			err = appendDecisionLogEvent(tx, decisionLogEvent(entity.DecisionLogEventDecisionChanged, restored[0], now))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Undoing a pass restores the like it replaced, and the match it had made
+		if restored[0].IsLike() && !change.Type.IsLike() {
+			if err := appendMatchLogEventIfLikedBack(tx, change.AuthorID, change.RecipientID, now); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&change).UpdateColumn("undone_at", now).Error; err != nil {
+			return fmt.Errorf("error marking decision change as undone: %w", translateError(err))
+		}
+
+		err = appendDecisionEvent(ctx, tx, entity.DecisionEvent{
+			AuthorID:    change.AuthorID,
+			RecipientID: change.RecipientID,
+			OldType:     &change.Type,
+			NewType:     change.PreviousType,
+			Cause:       entity.DecisionEventCauseUndo,
+			OccurredAt:  now,
+		})
+		if err != nil {
+			return err
+		}
+
+		matchedAfter, err := findMutualLike(tx, change.AuthorID, change.RecipientID)
+		if err != nil {
+			return err
+		}
+
+		matchDissolved = matchedBefore && !matchedAfter
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &change, matchDissolved, nil
+}
+
+// Appends the event of a change of a decision, in the transaction of the change
+func appendDecisionEvent(ctx context.Context, tx *gorm.DB, decisionEvent entity.DecisionEvent) error {
+	decisionEvent.RequestID = audit.RequestIDFromContext(ctx)
+
+	if err := tx.Create(&decisionEvent).Error; err != nil {
+		return fmt.Errorf("error recording decision event: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *explorerRepository) ListDecisionEvents(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int) ([]entity.DecisionEvent, error) {
+	var result []entity.DecisionEvent
+
+	queryBuilder := r.db.WithContext(ctx).Model(&entity.DecisionEvent{})
+
+	// idx_decision_events_author_id and idx_decision_events_recipient_id find both sides of the user
+	queryBuilder = queryBuilder.Where("author_id = ? OR recipient_id = ?", query.UserID, query.UserID)
+
+	if query.OtherUserID != 0 {
+		queryBuilder = queryBuilder.Where("author_id = ? OR recipient_id = ?", query.OtherUserID, query.OtherUserID)
+	}
+
+	if cursor != nil {
+		queryBuilder = queryBuilder.Where("id < ?", cursor.ID)
+	}
+
+	err := queryBuilder.Order("id DESC").Limit(limit).Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing decision events: %w", translateError(err))
 	}
 
 	return result, nil
 }
 
-func (r *explorerRepository) GetLikesCountByProfileId(ctx context.Context, profileID int) int64 {
-	var count int64
+// Appends an event to the decision log, in the transaction of the change
+func appendDecisionLogEvent(tx *gorm.DB, logEvent entity.DecisionLogEvent) error {
+	if err := tx.Create(&logEvent).Error; err != nil {
+		return fmt.Errorf("error appending to decision log: %w", translateError(err))
+	}
 
-	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
+	if logEvent.IsUserEvent() {
+		return nil
+	}
 
-	queryBuilder = queryBuilder.Where("recipient_id = ?", uint(profileID))
-	queryBuilder = queryBuilder.Where("liked = ?", true)
+	// The outbox is written in the same transaction, the event is published if and only if the change is committed
+	outboxEvent := entity.NewOutboxEvent(logEvent, audit.RequestIDFromContext(tx.Statement.Context))
+	if err := tx.Create(&outboxEvent).Error; err != nil {
+		return fmt.Errorf("error appending to outbox: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// Appends the match made by the new like of the author when the recipient likes the author.
+// The blocks don't matter here, the readers of the log hide the matches of the blocked pairs.
+func appendMatchLogEventIfLikedBack(tx *gorm.DB, authorID entity.UserID, recipientID entity.UserID, occurredAt time.Time) error {
+	var likedBackCount int64
 
-	queryBuilder.Count(&count)
+	queryBuilder := tx.Model(&entity.Decision{})
 
-	return count
+	queryBuilder = queryBuilder.Where("author_id = ?", recipientID)
+	queryBuilder = queryBuilder.Where("recipient_id = ?", authorID)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", nil)
+
+	if err := queryBuilder.Count(&likedBackCount).Error; err != nil {
+		return fmt.Errorf("error searching for like back: %w", translateError(err))
+	}
+
+	if likedBackCount == 0 {
+		return nil
+	}
+
+	return appendDecisionLogEvent(tx, entity.DecisionLogEvent{
+		Type:        entity.DecisionLogEventMatchCreated,
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		OccurredAt:  occurredAt,
+	})
 }
 
-func (r *explorerRepository) UpdateDecision(ctx context.Context, userID int, recipientUserId int, liked bool) error {
-	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
+// Event of the decision log holding the decision as it is after a change
+func decisionLogEvent(eventType entity.DecisionLogEventType, decision entity.Decision, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:              eventType,
+		AuthorID:          decision.AuthorID,
+		RecipientID:       decision.RecipientID,
+		DecisionID:        decision.ID,
+		NewType:           &decision.Type,
+		DecisionUpdatedAt: &decision.UpdatedAt,
+		OccurredAt:        occurredAt,
+	}
+}
 
-	queryBuilder = queryBuilder.Where("author_id = ?", uint(userID))
-	queryBuilder = queryBuilder.Where("recipient_id = ?", uint(recipientUserId))
+// Event of the decision log of a decision removed by an undo
+func removedDecisionLogEvent(decision entity.Decision, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:        entity.DecisionLogEventDecisionChanged,
+		AuthorID:    decision.AuthorID,
+		RecipientID: decision.RecipientID,
+		DecisionID:  decision.ID,
+		OccurredAt:  occurredAt,
+	}
+}
 
-	result := queryBuilder.Update("liked", liked)
+// Event of the decision log about the activity of a user or a block
+func userLogEvent(eventType entity.DecisionLogEventType, authorID entity.UserID, recipientID entity.UserID, occurredAt time.Time) entity.DecisionLogEvent {
+	return entity.DecisionLogEvent{
+		Type:        eventType,
+		AuthorID:    authorID,
+		RecipientID: recipientID,
+		OccurredAt:  occurredAt,
+	}
+}
 
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return domainError.NewDecisionNotFoundErr()
-		} else {
-			return fmt.Errorf("error updating decision: %w", result.Error)
-		}
+func (r *explorerRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) ([]entity.DecisionLogEvent, error) {
+	var result []entity.DecisionLogEvent
+
+	// A single statement, so an event committed late is never read after a later event of its pair
+	query := r.db.WithContext(ctx).Where("id > ?", afterID)
+	if len(missingIDs) > 0 {
+		query = query.Or("id IN ?", missingIDs)
+	}
+
+	err := query.
+		Order("id").
+		Limit(limit).
+		Find(&result).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading decision log: %w", translateError(err))
+	}
+
+	return result, nil
+}
+
+func (r *explorerRepository) ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) ([]entity.OutboxEvent, error) {
+	var result []entity.OutboxEvent
+
+	// The claimable events are the due and unclaimed ones, found with idx_outbox_events_unclaimed and
+	// idx_outbox_events_claimed, without an older event of their pair, looked up with idx_outbox_events_pair_key.
+	// The claim condition is repeated on the updated rows: a relay waiting on the row lock of another one re-checks it
+	// once the other commits, so an event is never claimed twice.
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE outbox_events
+		SET claimed_until = now() + ? * interval '1 millisecond'
+		WHERE id IN (
+			SELECT e.id FROM outbox_events e
+			WHERE e.next_attempt_at <= now()
+			AND (e.claimed_until IS NULL OR e.claimed_until <= now())
+			AND NOT EXISTS (SELECT 1 FROM outbox_events o2 WHERE o2.pair_key = e.pair_key AND o2.id < e.id)
+			ORDER BY e.id
+			LIMIT ?
+		)
+		AND (claimed_until IS NULL OR claimed_until <= now())
+		RETURNING *`, claimDuration.Milliseconds(), limit).
+		Scan(&result).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("error claiming outbox events: %w", translateError(err))
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	slices.SortFunc(result, func(a entity.OutboxEvent, b entity.OutboxEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result, nil
+}
+
+func (r *explorerRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", eventID).Delete(&entity.OutboxEvent{}).Error; err != nil {
+		return fmt.Errorf("error deleting outbox event: %w", translateError(err))
 	}
 
 	return nil
 }
 
-func (r *explorerRepository) FindMutualLike(ctx context.Context, userID int, recipientUserID int) bool {
+func (r *explorerRepository) RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error {
+	err := r.db.WithContext(ctx).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ?", eventID).
+		UpdateColumns(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": nextAttemptAt,
+			"claimed_until":   nil,
+			"last_error":      lastError,
+		}).Error
+
+	if err != nil {
+		return fmt.Errorf("error recording failed outbox event attempt: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// Namespace of the advisory locks on the decisions of an author, the single key locks are used by the migrations
+const authorLockNamespace = 1
+
+// Namespace of the advisory locks on the decisions and the blocks between two users
+const pairLockNamespace = 2
+
+// Takes a lock on the decisions of the author until the end of the transaction. The lock keys are 32 bits,
+// the BIGINT IDs are hashed into them, a collision only serialises the decisions of 2 authors.
+func lockAuthor(tx *gorm.DB, authorID entity.UserID) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashint8(?))", authorLockNamespace, authorID).Error; err != nil {
+		return fmt.Errorf("error locking decisions of author: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// Takes a lock on the decisions and the blocks between the two users, in both directions, until the end of the
+// transaction. The decision log events of a pair are appended in the order of their commits, and of the match only one
+// of two crossed likes sees the other. Taken after the author lock when there is one, the key is a hash like the one
+// of the author.
+func lockPair(tx *gorm.DB, userID entity.UserID, otherUserID entity.UserID) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", pairLockNamespace, entity.PairKey(userID, otherUserID)).Error; err != nil {
+		return fmt.Errorf("error locking decisions of pair: %w", translateError(err))
+	}
+
+	return nil
+}
+
+func (r *explorerRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
+	return findMutualLike(r.db.WithContext(ctx), userID, recipientUserID)
+}
+
+// Finds a mutual like with the given connection, which may be a transaction. Like ListMatchesForUserId, the likes
+// of the inactive users and of the blocked pairs are left out.
+func findMutualLike(db *gorm.DB, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
 	var actorLikesCount int64
 	var recipientLikesCount int64
 
-	queryBuilder := r.db.WithContext(ctx).Model(&entity.Decision{})
+	queryBuilder := db.Model(&entity.Decision{})
 
 	queryBuilder = queryBuilder.Where("author_id = ?", userID)
 	queryBuilder = queryBuilder.Where("recipient_id = ?", recipientUserID)
-	queryBuilder = queryBuilder.Where("liked = ?", true)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", nil)
+	queryBuilder = withActiveAuthors(queryBuilder, "decisions.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "decisions.author_id", "decisions.recipient_id")
 
-	queryBuilder.Count(&actorLikesCount)
+	if err := queryBuilder.Count(&actorLikesCount).Error; err != nil {
+		return false, fmt.Errorf("error counting actor likes: %w", translateError(err))
+	}
 
-	queryBuilder = r.db.WithContext(ctx).Model(&entity.Decision{})
+	queryBuilder = db.Model(&entity.Decision{})
 
 	queryBuilder = queryBuilder.Where("author_id = ?", recipientUserID)
 	queryBuilder = queryBuilder.Where("recipient_id = ?", userID)
-	queryBuilder = queryBuilder.Where("liked = ?", true)
+	queryBuilder = withLikeTypes(queryBuilder, "decisions.type", nil)
+	queryBuilder = withActiveAuthors(queryBuilder, "decisions.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "decisions.author_id", "decisions.recipient_id")
+
+	if err := queryBuilder.Count(&recipientLikesCount).Error; err != nil {
+		return false, fmt.Errorf("error counting recipient likes: %w", translateError(err))
+	}
+
+	// The unique index on (author_id, recipient_id) guarantees that there is at most 1 decision between actors and recipients
+	return actorLikesCount == 1 && recipientLikesCount == 1, nil
+}
+
+func (r *explorerRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) ([]entity.Match, error) {
+	var result []entity.Match
+
+	// The decisions table is joined against itself: the decisions liked by the user (mine)
+	// with the decisions that liked the user back (theirs).
+	// idx_decisions_author_type finds mine and idx_decisions_author_recipient finds theirs.
+	matchedAt := "GREATEST(mine.updated_at, theirs.updated_at)"
+
+	queryBuilder := r.db.WithContext(ctx).
+		Table("decisions AS mine").
+		Select("theirs.author_id AS user_id, " + matchedAt + " AS matched_at").
+		Joins("JOIN decisions AS theirs ON theirs.author_id = mine.recipient_id AND theirs.recipient_id = mine.author_id")
 
-	queryBuilder.Count(&recipientLikesCount)
+	queryBuilder = queryBuilder.Where("mine.author_id = ?", userID)
+	queryBuilder = withLikeTypes(queryBuilder, "mine.type", nil)
+	queryBuilder = withLikeTypes(queryBuilder, "theirs.type", nil)
+	queryBuilder = withActiveAuthors(queryBuilder, "theirs.author_id")
+	queryBuilder = withoutBlockedPairs(queryBuilder, "mine.author_id", "theirs.author_id")
 
-	// We are assuming that we will only store 1 decision between actors and recipients
-	return actorLikesCount == 1 && recipientLikesCount == 1
+	if cursor != nil {
+		queryBuilder = queryBuilder.Where("("+matchedAt+", theirs.author_id) < (?, ?)", cursor.MatchedAt, cursor.UserID)
+	}
+
+	err := queryBuilder.
+		Order("matched_at DESC").
+		Order("user_id DESC").
+		Limit(limit).
+		Scan(&result).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("error searching for matches of user id: %w", translateError(err))
+	}
+
+	return result, nil
+}
+
+func (r *explorerRepository) BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The blocks and unblocks of the pair are logged in the order of their commits
+		if err := lockPair(tx, blockerID, blockedID); err != nil {
+			return err
+		}
+
+		block := entity.Block{
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+			CreatedAt: time.Now(),
+		}
+
+		// INSERT ... ON CONFLICT DO NOTHING, the first block date is kept and blocking again isn't logged
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block)
+		if result.Error != nil {
+			return fmt.Errorf("error blocking user in db: %w", translateError(result.Error))
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return appendDecisionLogEvent(tx, userLogEvent(entity.DecisionLogEventUserBlocked, blockerID, blockedID, block.CreatedAt))
+	})
+}
+
+func (r *explorerRepository) UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockPair(tx, blockerID, blockedID); err != nil {
+			return err
+		}
+
+		result := tx.
+			Where("blocker_id = ?", blockerID).
+			Where("blocked_id = ?", blockedID).
+			Delete(&entity.Block{})
+
+		if result.Error != nil {
+			return fmt.Errorf("error unblocking user in db: %w", translateError(result.Error))
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return appendDecisionLogEvent(tx, userLogEvent(entity.DecisionLogEventUserUnblocked, blockerID, blockedID, time.Now()))
+	})
+}
+
+func (r *explorerRepository) IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&entity.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherUserID, otherUserID, userID).
+		Count(&count).Error
+
+	if err != nil {
+		return false, fmt.Errorf("error searching for blocks between users: %w", translateError(err))
+	}
+
+	return count > 0, nil
+}
+
+func (r *explorerRepository) CreateReport(ctx context.Context, report *entity.Report) error {
+	if err := r.db.WithContext(ctx).Create(report).Error; err != nil {
+		return fmt.Errorf("error creating report in db: %w", translateError(err))
+	}
+
+	return nil
 }
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lokker96/grpc_project/domain/auth"
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/domain/repository/repositorytest"
+	"github.com/lokker96/grpc_project/domain/service"
+	"github.com/lokker96/grpc_project/infrastructure/metrics"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/postgres/migration"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	"github.com/lokker96/grpc_project/infrastructure/pubsub"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// These tests need a real postgres database, they are skipped unless POSTGRES_TEST_DSN is set.
+// Example: POSTGRES_TEST_DSN="host=localhost user=testingUser password=testingPassword dbname=explorer_test port=5432 sslmode=disable"
+// Careful: the public schema of the database is dropped and migrated again.
+func newTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set, skipping postgres tests")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func Test_ExplorerRepositoryConformance(t *testing.T) {
+	repositorytest.RunExplorerRepositoryTests(t, func(t *testing.T) repository.ExplorerRepository {
+		return NewExplorerRepository(newTestDB(t))
+	})
+}
+
+func Test_PutDecision_ConcurrentSamePair(t *testing.T) {
+	db := newTestDB(t)
+
+	// The calls are made by a service, allowed to decide for any user
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "test", Roles: []auth.Role{auth.RoleService}})
+
+	explorerRepository := NewExplorerRepository(db)
+
+	// Users 1 and 2
+	for i := 0; i < 2; i++ {
+		if err := explorerRepository.CreateUser(ctx, &entity.User{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	likeHub := pubsub.NewLikeHub(pubsub.DefaultLikeHubConfig())
+	defer likeHub.Close()
+
+	explorerServer := service.NewExplorerServer(explorerRepository, likeHub, metrics.NewDecisionRecorder(prometheus.NewRegistry()))
+
+	// User 1 swipes on user 2 from many devices at the same time, the last call likes user 2
+	const parallelCalls = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, parallelCalls)
+
+	for i := 0; i < parallelCalls; i++ {
+		wg.Add(1)
+		go func(liked bool) {
+			defer wg.Done()
+
+			_, err := explorerServer.PutDecision(ctx, &explorev2.PutDecisionRequest{
+				ActorUserId:     1,
+				RecipientUserId: 2,
+				LikedRecipient:  liked,
+			})
+			errs <- err
+		}(i%2 == 0)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var count int64
+	if err := db.Model(&entity.Decision{}).Where("author_id = ? AND recipient_id = ?", 1, 2).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, count, int64(1))
+
+	// Both users like each other, only one row exists per pair so the mutual like must be found
+	_, err := explorerServer.PutDecision(ctx, &explorev2.PutDecisionRequest{
+		ActorUserId:     1,
+		RecipientUserId: 2,
+		LikedRecipient:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := explorerServer.PutDecision(ctx, &explorev2.PutDecisionRequest{
+		ActorUserId:     2,
+		RecipientUserId: 1,
+		LikedRecipient:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, response.MutualLikes, true)
+}
+
+func Test_TranslateError(t *testing.T) {
+	testCases := []struct {
+		name                string
+		err                 error
+		expectedUnavailable bool
+	}{
+		{name: "Refused connection", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, expectedUnavailable: true},
+		{name: "Lost connection", err: &pgconn.PgError{Code: "08006"}, expectedUnavailable: true},
+		{name: "Statement timeout", err: &pgconn.PgError{Code: "57014"}, expectedUnavailable: true},
+		{name: "Server shutting down", err: &pgconn.PgError{Code: "57P01"}, expectedUnavailable: true},
+		{name: "Syntax error", err: &pgconn.PgError{Code: "42601"}},
+		{name: "Unique violation", err: &pgconn.PgError{Code: "23505"}},
+	}
+
+	for _, testCase := range testCases {
+		var databaseErr domainError.DatabaseErr
+		if !errors.As(translateError(testCase.err), &databaseErr) {
+			t.Fatalf("%s: expected a database error", testCase.name)
+		}
+
+		assert.Equal(t, databaseErr.Unavailable(), testCase.expectedUnavailable, testCase.name)
+	}
+
+	assert.Equal(t, translateError(&pgconn.PgError{Code: "23503"}), domainError.NewUserNotFoundErr())
+}
@@ -0,0 +1,317 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The SQL migrations are embedded in the binary. Every migration is made of 2 files:
+// <version>_<name>.up.sql and <version>_<name>.down.sql, versions are applied in ascending order.
+//
+//go:embed sql/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Arbitrary key of the postgres advisory lock held while a migration is applied,
+// it prevents 2 processes from migrating the schema at the same time
+const migrationLockKey = 72_616_432
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Row of the schema_migrations table, there is 1 row per applied migration
+type appliedMigration struct {
+	Version   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Returned when the database schema is older than the migrations embedded in the binary
+type SchemaBehindErr struct {
+	Current uint
+	Latest  uint
+}
+
+func (e SchemaBehindErr) Error() string {
+	return fmt.Sprintf("error, database schema is at version %d but version %d is required, run the migrate command", e.Current, e.Latest)
+}
+
+// Reads the embedded migrations sorted by version
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("error on reading migration files: %w", err)
+	}
+
+	byVersion := map[uint]*Migration{}
+
+	for _, entry := range entries {
+		matches := migrationFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("error, unexpected migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("error, invalid migration version: %s", entry.Name())
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error on reading migration file %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: matches[2]}
+			byVersion[uint(version)] = migration
+		} else if migration.Name != matches[2] {
+			return nil, fmt.Errorf("error, migration version %d is used by %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("error, migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// The migrator applies and reverts the embedded migrations, keeping track of them in schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Version of the latest embedded migration, the one the code expects
+func (m *Migrator) LatestVersion() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version of the latest migration applied to the database, 0 when none has been applied
+func (m *Migrator) Version(ctx context.Context) (uint, error) {
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return 0, err
+	}
+
+	return currentVersion(m.db.WithContext(ctx))
+}
+
+// Returns the embedded migrations that haven't been applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Returns a SchemaBehindErr if some migrations haven't been applied, the server must not start in that case
+func (m *Migrator) EnsureUpToDate(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version < m.LatestVersion() {
+		return SchemaBehindErr{Current: version, Latest: m.LatestVersion()}
+	}
+
+	return nil
+}
+
+// Applies up to steps pending migrations, all of them when steps is 0 or less.
+// Every migration runs in its own transaction together with its schema_migrations row.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0)
+
+	for _, migration := range m.migrations {
+		if steps > 0 && len(applied) == steps {
+			break
+		}
+
+		done := false
+
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+				return fmt.Errorf("error on locking migrations: %w", err)
+			}
+
+			// Read again while holding the lock, another process may have applied it
+			version, err := currentVersion(tx)
+			if err != nil {
+				return err
+			}
+
+			if migration.Version <= version {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return fmt.Errorf("error on applying migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			err = tx.Create(&appliedMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+			if err != nil {
+				return fmt.Errorf("error on recording migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			done = true
+
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// Reverts up to steps applied migrations starting from the latest one, all of them when steps is 0 or less
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	reverted := make([]Migration, 0)
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+
+		if steps > 0 && len(reverted) == steps {
+			break
+		}
+
+		done := false
+
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+				return fmt.Errorf("error on locking migrations: %w", err)
+			}
+
+			version, err := currentVersion(tx)
+			if err != nil {
+				return err
+			}
+
+			if migration.Version != version {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return fmt.Errorf("error on reverting migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			if err := tx.Delete(&appliedMigration{}, migration.Version).Error; err != nil {
+				return fmt.Errorf("error on removing migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			done = true
+
+			return nil
+		})
+		if err != nil {
+			return reverted, err
+		}
+
+		if done {
+			reverted = append(reverted, migration)
+		}
+	}
+
+	return reverted, nil
+}
+
+func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
+	err := m.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`).Error
+	if err != nil {
+		return fmt.Errorf("error on creating schema_migrations table: %w", err)
+	}
+
+	return nil
+}
+
+func currentVersion(db *gorm.DB) (uint, error) {
+	var version uint
+
+	err := db.Model(&appliedMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return 0, fmt.Errorf("error on reading schema version: %w", err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_Migrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	// Versions start at 1 and have no gaps so the order can't be ambiguous
+	for i, migration := range migrations {
+		assert.Equal(t, migration.Version, uint(i+1))
+
+		if migration.Up == "" || migration.Down == "" {
+			t.Fatalf("migration %d_%s is missing its up or down sql", migration.Version, migration.Name)
+		}
+	}
+}
+
+// Needs a real postgres database, skipped unless POSTGRES_TEST_DSN is set.
+// Careful: the public schema of the database is dropped.
+func Test_Migrator_UpAndDown(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set, skipping postgres tests")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new database is behind
+	var schemaBehindErr SchemaBehindErr
+	if !errors.As(migrator.EnsureUpToDate(ctx), &schemaBehindErr) {
+		t.Fatal("expected the schema to be behind")
+	}
+
+	applied, err := migrator.Up(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(applied), 1)
+
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrator.EnsureUpToDate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Applying again is a no-op
+	applied, err = migrator.Up(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(applied), 0)
+
+	reverted, err := migrator.Down(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(reverted), int(migrator.LatestVersion()))
+
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, version, uint(0))
+}
@@ -0,0 +1,5 @@
+CREATE TABLE users (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
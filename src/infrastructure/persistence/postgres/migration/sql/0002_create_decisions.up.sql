@@ -0,0 +1,19 @@
+CREATE TABLE decisions (
+    id           BIGSERIAL PRIMARY KEY,
+    author_id    BIGINT NOT NULL,
+    recipient_id BIGINT NOT NULL,
+    liked        BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    CONSTRAINT fk_decisions_author FOREIGN KEY (author_id) REFERENCES users (id),
+    CONSTRAINT fk_decisions_recipient FOREIGN KEY (recipient_id) REFERENCES users (id)
+);
+
+-- Only 1 decision per pair, PutDecision relies on it to upsert with ON CONFLICT
+CREATE UNIQUE INDEX idx_decisions_author_recipient ON decisions (author_id, recipient_id);
+
+-- Liker lists of a recipient, paged by (updated_at, id)
+CREATE INDEX idx_decisions_recipient_liked_updated ON decisions (recipient_id, liked, updated_at, id);
+
+-- Decisions liked by an author, used to find the matches
+CREATE INDEX idx_decisions_author_liked ON decisions (author_id, liked);
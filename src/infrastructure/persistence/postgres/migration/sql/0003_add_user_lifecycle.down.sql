@@ -0,0 +1,5 @@
+DROP INDEX idx_users_inactive;
+
+ALTER TABLE users
+    DROP COLUMN deactivated_at,
+    DROP COLUMN deleted_at;
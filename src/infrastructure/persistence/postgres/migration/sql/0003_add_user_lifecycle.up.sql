@@ -0,0 +1,8 @@
+-- Deactivated accounts are kept but their likes are hidden, deleted accounts are soft deleted
+-- until their data is erased
+ALTER TABLE users
+    ADD COLUMN deactivated_at TIMESTAMPTZ,
+    ADD COLUMN deleted_at     TIMESTAMPTZ;
+
+-- Few users are inactive, the liker lists and the counts look them up to hide their likes
+CREATE INDEX idx_users_inactive ON users (id) WHERE deactivated_at IS NOT NULL OR deleted_at IS NOT NULL;
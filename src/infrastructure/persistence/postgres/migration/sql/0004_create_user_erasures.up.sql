@@ -0,0 +1,7 @@
+-- Audit trail of the erased users, it must never hold personal data: no user id, no subject
+CREATE TABLE user_erasures (
+    id               BIGSERIAL PRIMARY KEY,
+    requested_by     TEXT NOT NULL,
+    decisions_erased BIGINT NOT NULL,
+    erased_at        TIMESTAMPTZ
+);
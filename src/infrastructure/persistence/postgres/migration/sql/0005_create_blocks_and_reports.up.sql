@@ -0,0 +1,27 @@
+-- Only 1 block per pair and direction, blocking again is a no-op
+CREATE TABLE blocks (
+    blocker_id BIGINT NOT NULL,
+    blocked_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT fk_blocks_blocker FOREIGN KEY (blocker_id) REFERENCES users (id),
+    CONSTRAINT fk_blocks_blocked FOREIGN KEY (blocked_id) REFERENCES users (id)
+);
+
+-- The primary key finds the blocks made by a user, this one the blocks received by a user,
+-- both are needed to exclude a pair in both directions
+CREATE INDEX idx_blocks_blocked_blocker ON blocks (blocked_id, blocker_id);
+
+CREATE TABLE reports (
+    id          BIGSERIAL PRIMARY KEY,
+    reporter_id BIGINT NOT NULL,
+    reported_id BIGINT NOT NULL,
+    reason      TEXT NOT NULL,
+    details     TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ,
+    CONSTRAINT fk_reports_reporter FOREIGN KEY (reporter_id) REFERENCES users (id),
+    CONSTRAINT fk_reports_reported FOREIGN KEY (reported_id) REFERENCES users (id)
+);
+
+-- Reports received by a user, for the moderation tools
+CREATE INDEX idx_reports_reported_created ON reports (reported_id, created_at);
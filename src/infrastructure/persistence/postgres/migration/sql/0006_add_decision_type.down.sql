@@ -0,0 +1,9 @@
+-- The super likes become likes, there is no way back
+ALTER TABLE decisions ADD COLUMN liked BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE decisions SET liked = type IN ('like', 'super_like');
+
+ALTER TABLE decisions DROP COLUMN type;
+
+CREATE INDEX idx_decisions_recipient_liked_updated ON decisions (recipient_id, liked, updated_at, id);
+CREATE INDEX idx_decisions_author_liked ON decisions (author_id, liked);
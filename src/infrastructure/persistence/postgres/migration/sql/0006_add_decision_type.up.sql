@@ -0,0 +1,18 @@
+-- The liked boolean becomes a type: a like stays a like, a pass stays a pass. A super like is a like that is
+-- notified as such and ranks higher in the liker lists.
+ALTER TABLE decisions ADD COLUMN type TEXT;
+
+UPDATE decisions SET type = CASE WHEN liked THEN 'like' ELSE 'pass' END;
+
+ALTER TABLE decisions
+    ALTER COLUMN type SET NOT NULL,
+    ADD CONSTRAINT chk_decisions_type CHECK (type IN ('pass', 'like', 'super_like'));
+
+-- Drops idx_decisions_recipient_liked_updated and idx_decisions_author_liked with it
+ALTER TABLE decisions DROP COLUMN liked;
+
+-- Liker lists of a recipient, filtered by type and paged by (updated_at, id)
+CREATE INDEX idx_decisions_recipient_type_updated ON decisions (recipient_id, type, updated_at, id);
+
+-- Decisions liked by an author, used to find the matches
+CREATE INDEX idx_decisions_author_type ON decisions (author_id, type);
@@ -0,0 +1,21 @@
+-- Every upsert of a decision adds a row with the state it replaced, so the last decision of a user can be undone
+CREATE TABLE decision_history (
+    id                  BIGSERIAL PRIMARY KEY,
+    author_id           BIGINT NOT NULL,
+    recipient_id        BIGINT NOT NULL,
+    type                TEXT NOT NULL,
+    previous_type       TEXT,
+    previous_updated_at TIMESTAMPTZ,
+    changed_at          TIMESTAMPTZ NOT NULL,
+    undone_at           TIMESTAMPTZ,
+    CONSTRAINT fk_decision_history_author FOREIGN KEY (author_id) REFERENCES users (id),
+    CONSTRAINT fk_decision_history_recipient FOREIGN KEY (recipient_id) REFERENCES users (id),
+    CONSTRAINT chk_decision_history_type CHECK (type IN ('pass', 'like', 'super_like')),
+    CONSTRAINT chk_decision_history_previous_type CHECK (previous_type IN ('pass', 'like', 'super_like'))
+);
+
+-- Finds the most recent changes of an author, and the changes to erase with the author
+CREATE INDEX idx_decision_history_author_id ON decision_history (author_id, id);
+
+-- The changes received by a user, for its erasure
+CREATE INDEX idx_decision_history_recipient ON decision_history (recipient_id);
@@ -0,0 +1,2 @@
+DROP TABLE decision_events;
+DROP FUNCTION reject_decision_events_update();
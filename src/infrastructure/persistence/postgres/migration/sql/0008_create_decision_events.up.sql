@@ -0,0 +1,31 @@
+-- Audit trail of the decisions: a row per change, written in the same transaction as the change
+CREATE TABLE decision_events (
+    id           BIGSERIAL PRIMARY KEY,
+    author_id    BIGINT NOT NULL,
+    recipient_id BIGINT NOT NULL,
+    old_type     TEXT,
+    new_type     TEXT,
+    cause        TEXT NOT NULL,
+    request_id   TEXT NOT NULL DEFAULT '',
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_decision_events_author FOREIGN KEY (author_id) REFERENCES users (id),
+    CONSTRAINT fk_decision_events_recipient FOREIGN KEY (recipient_id) REFERENCES users (id),
+    CONSTRAINT chk_decision_events_old_type CHECK (old_type IN ('pass', 'like', 'super_like')),
+    CONSTRAINT chk_decision_events_new_type CHECK (new_type IN ('pass', 'like', 'super_like')),
+    CONSTRAINT chk_decision_events_cause CHECK (cause IN ('put', 'undo'))
+);
+
+-- The events of a user, authored or received, most recent first
+CREATE INDEX idx_decision_events_author_id ON decision_events (author_id, id);
+CREATE INDEX idx_decision_events_recipient_id ON decision_events (recipient_id, id);
+
+-- The events are never updated, only the erasure of a user deletes some
+CREATE FUNCTION reject_decision_events_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'decision_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_decision_events_append_only
+    BEFORE UPDATE ON decision_events
+    FOR EACH ROW EXECUTE FUNCTION reject_decision_events_update();
@@ -0,0 +1,2 @@
+DROP TABLE decision_log;
+DROP FUNCTION reject_decision_log_update();
@@ -0,0 +1,46 @@
+-- Log the read projections are built from: a row per change of a decision, with the decision as it is after the
+-- change, and a row per match. Written in the same transaction as the change.
+CREATE TABLE decision_log (
+    id                  BIGSERIAL PRIMARY KEY,
+    type                TEXT NOT NULL,
+    author_id           BIGINT NOT NULL,
+    recipient_id        BIGINT NOT NULL,
+    decision_id         BIGINT NOT NULL DEFAULT 0,
+    new_type            TEXT,
+    decision_updated_at TIMESTAMPTZ,
+    occurred_at         TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_decision_log_author FOREIGN KEY (author_id) REFERENCES users (id),
+    CONSTRAINT fk_decision_log_recipient FOREIGN KEY (recipient_id) REFERENCES users (id),
+    CONSTRAINT chk_decision_log_type CHECK (type IN ('decision_made', 'decision_changed', 'match_created')),
+    CONSTRAINT chk_decision_log_new_type CHECK (new_type IN ('pass', 'like', 'super_like'))
+);
+
+-- The events to erase with a user
+CREATE INDEX idx_decision_log_author_id ON decision_log (author_id);
+CREATE INDEX idx_decision_log_recipient_id ON decision_log (recipient_id);
+
+-- The log starts with the decisions as they are, then their matches made by the latest of the two likes
+INSERT INTO decision_log (type, author_id, recipient_id, decision_id, new_type, decision_updated_at, occurred_at)
+SELECT 'decision_made', author_id, recipient_id, id, type, updated_at, created_at
+FROM decisions
+ORDER BY updated_at, id;
+
+INSERT INTO decision_log (type, author_id, recipient_id, occurred_at)
+SELECT 'match_created', mine.author_id, mine.recipient_id, mine.updated_at
+FROM decisions AS mine
+JOIN decisions AS theirs ON theirs.author_id = mine.recipient_id AND theirs.recipient_id = mine.author_id
+WHERE mine.type IN ('like', 'super_like')
+  AND theirs.type IN ('like', 'super_like')
+  AND (mine.updated_at, mine.id) > (theirs.updated_at, theirs.id)
+ORDER BY mine.updated_at, mine.id;
+
+-- The events are never updated, only the erasure of a user deletes some
+CREATE FUNCTION reject_decision_log_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'decision_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_decision_log_append_only
+    BEFORE UPDATE ON decision_log
+    FOR EACH ROW EXECUTE FUNCTION reject_decision_log_update();
@@ -0,0 +1,32 @@
+-- Transactional outbox: a copy of every decision log event, written in the same transaction as the change and
+-- deleted once a relay has published it. The decisions made before this migration are not published.
+CREATE TABLE outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    type            TEXT NOT NULL,
+    pair_key        TEXT NOT NULL,
+    author_id       BIGINT NOT NULL,
+    recipient_id    BIGINT NOT NULL,
+    decision_type   TEXT,
+    request_id      TEXT NOT NULL DEFAULT '',
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    claimed_until   TIMESTAMPTZ,
+    last_error      TEXT NOT NULL DEFAULT '',
+    CONSTRAINT fk_outbox_events_author FOREIGN KEY (author_id) REFERENCES users (id),
+    CONSTRAINT fk_outbox_events_recipient FOREIGN KEY (recipient_id) REFERENCES users (id),
+    CONSTRAINT chk_outbox_events_type CHECK (type IN ('decision_made', 'decision_changed', 'match_created')),
+    CONSTRAINT chk_outbox_events_decision_type CHECK (decision_type IN ('pass', 'like', 'super_like'))
+);
+
+-- The oldest event of every pair, the only one a relay can claim
+CREATE INDEX idx_outbox_events_pair_key ON outbox_events (pair_key, id);
+
+-- The due events waiting for a relay, the claims look them up instead of scanning the table. The claimed events are
+-- few, the ones of a relay that died are found again once their claim expired.
+CREATE INDEX idx_outbox_events_unclaimed ON outbox_events (next_attempt_at, id) WHERE claimed_until IS NULL;
+CREATE INDEX idx_outbox_events_claimed ON outbox_events (claimed_until) WHERE claimed_until IS NOT NULL;
+
+-- The events to erase with a user
+CREATE INDEX idx_outbox_events_author_id ON outbox_events (author_id);
+CREATE INDEX idx_outbox_events_recipient_id ON outbox_events (recipient_id);
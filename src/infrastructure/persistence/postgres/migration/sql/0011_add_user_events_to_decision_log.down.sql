@@ -0,0 +1,4 @@
+DELETE FROM decision_log WHERE type IN ('user_deactivated', 'user_deleted', 'user_blocked', 'user_unblocked');
+
+ALTER TABLE decision_log DROP CONSTRAINT chk_decision_log_type;
+ALTER TABLE decision_log ADD CONSTRAINT chk_decision_log_type CHECK (type IN ('decision_made', 'decision_changed', 'match_created'));
@@ -0,0 +1,25 @@
+-- The read projections hide the likes of the inactive users and of the blocked pairs from the log alone: the
+-- deactivations, the deletions, the blocks and the unblocks are logged too. They are not copied into the outbox.
+ALTER TABLE decision_log DROP CONSTRAINT chk_decision_log_type;
+ALTER TABLE decision_log ADD CONSTRAINT chk_decision_log_type CHECK (type IN (
+    'decision_made', 'decision_changed', 'match_created',
+    'user_deactivated', 'user_deleted', 'user_blocked', 'user_unblocked'
+));
+
+-- The log goes on with the users and the blocks as they are, an event of a user has it as author and recipient
+INSERT INTO decision_log (type, author_id, recipient_id, occurred_at)
+SELECT 'user_deactivated', id, id, deactivated_at
+FROM users
+WHERE deactivated_at IS NOT NULL
+ORDER BY deactivated_at, id;
+
+INSERT INTO decision_log (type, author_id, recipient_id, occurred_at)
+SELECT 'user_deleted', id, id, deleted_at
+FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at, id;
+
+INSERT INTO decision_log (type, author_id, recipient_id, occurred_at)
+SELECT 'user_blocked', blocker_id, blocked_id, COALESCE(created_at, now())
+FROM blocks
+ORDER BY created_at, blocker_id, blocked_id;
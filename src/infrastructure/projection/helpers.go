@@ -0,0 +1,30 @@
+package projection
+
+import (
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+// Keyset comparison used by the pagination: (at, id) < (cursorAt, cursorID)
+func isBefore[ID ~int64](at time.Time, id ID, cursorAt time.Time, cursorID ID) bool {
+	return at.Before(cursorAt) || (at.Equal(cursorAt) && id < cursorID)
+}
+
+// Keyset comparison of the liker lists, the rank of the type comes first in the ranked order
+func isLikerBefore(order repository.LikersOrder, decision entity.Decision, cursorRank int, cursorAt time.Time, cursorID int64) bool {
+	if order == repository.LikersOrderRanked && decision.Type.Rank() != cursorRank {
+		return decision.Type.Rank() < cursorRank
+	}
+
+	return isBefore(decision.UpdatedAt, decision.ID, cursorAt, cursorID)
+}
+
+func latest(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,413 @@
+package projection
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+)
+
+type LikesProjectionConfig struct {
+	BatchSize       int           // Events read from the decision log per query
+	GapTimeout      time.Duration // How long a missing event of the log is waited for before being skipped
+	CatchUpInterval time.Duration // How often the log is read in the background
+}
+
+func DefaultLikesProjectionConfig() LikesProjectionConfig {
+	return LikesProjectionConfig{
+		BatchSize:       1000,
+		GapTimeout:      10 * time.Second,
+		CatchUpInterval: 100 * time.Millisecond,
+	}
+}
+
+// The likes projection serves the read side of the decisions from memory. Its projectors replay the decision log
+// into the liker lists, the like counters, the match table, the inactive users and the blocks, so the reads never
+// query the database. It catches up with the log in the background once started, the reads lag behind the writes
+// by up to CatchUpInterval and don't wait for the catch ups.
+//
+// The IDs of the log are allocated before their transaction commits, so an event can show up after an event with a
+// greater ID, or never when its transaction rolled back. The projection applies every event it reads once and waits
+// up to GapTimeout for the missing IDs below the last one. The watermark then moves over them, but they are still
+// read with every catch up and applied if they show up, so the IDs of the rolled back and erased events are kept
+// until the next rebuild. The events of a pair of users are committed in order, and an event committed late is read
+// in the same snapshot as the later events of its pair, which is all the projectors rely on.
+type LikesProjection struct {
+	reader   repository.ExplorerRepository
+	config   LikesProjectionConfig
+	now      func() time.Time
+	started  bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+
+	catchUpMu sync.Mutex // Serializes the catch ups, the rebuilds and the erasures, held before mu
+	mu        sync.RWMutex
+	watermark int64               // Every event up to this ID has been applied or skipped
+	applied   map[int64]struct{}  // Events applied after the watermark
+	gaps      map[int64]time.Time // Missing events after the watermark, with when they were first missed
+	skipped   map[int64]struct{}  // Missing events the watermark moved over, still read in case they commit
+	likers    *likerLists         // Likes received by every recipient
+	counters  *likeCounters       // Likes received by every recipient, by type
+	matches   *matchTable         // Matches of every user
+	inactive  *inactiveUsers      // Deactivated and deleted users
+	blocks    *blockTable         // Blocks of every user
+}
+
+func NewLikesProjection(reader repository.ExplorerRepository, config LikesProjectionConfig) *LikesProjection {
+	p := &LikesProjection{
+		reader: reader,
+		config: config,
+		now:    time.Now,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	p.reset()
+
+	return p
+}
+
+// Must be called while holding the write lock
+func (p *LikesProjection) reset() {
+	p.watermark = 0
+	p.applied = map[int64]struct{}{}
+	p.gaps = map[int64]time.Time{}
+	p.skipped = map[int64]struct{}{}
+	p.likers = newLikerLists()
+	p.counters = newLikeCounters()
+	p.matches = newMatchTable()
+	p.inactive = newInactiveUsers()
+	p.blocks = newBlockTable()
+}
+
+// Catches up with the log in the background every CatchUpInterval until Stop
+func (p *LikesProjection) Start() {
+	p.started = true
+	go p.loop()
+}
+
+// Waits for the catch up in progress, if any, and stops catching up. It must not be called concurrently with Start.
+func (p *LikesProjection) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+
+		if p.started {
+			<-p.done
+		}
+	})
+}
+
+func (p *LikesProjection) loop() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.config.CatchUpInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if err := p.catchUp(context.Background()); err != nil {
+			log.Printf("projection: catching up with the decision log failed: %s", err.Error())
+		}
+	}
+}
+
+// Drops the read models and replays the whole decision log into them
+func (p *LikesProjection) Rebuild(ctx context.Context) error {
+	p.catchUpMu.Lock()
+	defer p.catchUpMu.Unlock()
+
+	p.mu.Lock()
+	p.reset()
+	p.mu.Unlock()
+
+	return p.catchUpLocked(ctx)
+}
+
+// Called once the user has been erased, its events are gone from the log but not from the read models.
+// Like Rebuild it waits for the catch up in progress, which may have read the events of the user before the erasure.
+func (p *LikesProjection) Forget(userID entity.UserID) {
+	p.catchUpMu.Lock()
+	defer p.catchUpMu.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	removals := make([]entity.DecisionLogEvent, 0)
+
+	for recipientID, likers := range p.likers.byRecipient {
+		for authorID := range likers {
+			if authorID == userID || recipientID == userID {
+				removals = append(removals, entity.DecisionLogEvent{
+					Type:        entity.DecisionLogEventDecisionChanged,
+					AuthorID:    authorID,
+					RecipientID: recipientID,
+				})
+			}
+		}
+	}
+
+	for _, removal := range removals {
+		p.apply(removal)
+	}
+
+	p.inactive.forget(userID)
+	p.blocks.forget(userID)
+}
+
+func (p *LikesProjection) catchUp(ctx context.Context) error {
+	p.catchUpMu.Lock()
+	defer p.catchUpMu.Unlock()
+
+	return p.catchUpLocked(ctx)
+}
+
+// Applies the events committed since the last catch up, must be called while holding catchUpMu
+func (p *LikesProjection) catchUpLocked(ctx context.Context) error {
+	afterID := p.watermark
+
+	for {
+		p.mu.RLock()
+		skippedIDs := slices.Collect(maps.Keys(p.skipped))
+		p.mu.RUnlock()
+
+		events, err := p.reader.ReadDecisionLog(ctx, afterID, skippedIDs, p.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		for _, logEvent := range events {
+			// A skipped event finally committed, it comes before the others
+			if _, ok := p.skipped[logEvent.ID]; ok {
+				delete(p.skipped, logEvent.ID)
+				p.apply(logEvent)
+
+				continue
+			}
+
+			p.applyOnce(logEvent, afterID)
+			afterID = logEvent.ID
+		}
+		p.mu.Unlock()
+
+		if len(events) < p.config.BatchSize {
+			break
+		}
+	}
+
+	p.mu.Lock()
+	p.advanceWatermark()
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Applies the event unless it has already been, the IDs missing between previousID and the event are recorded as
+// gaps. Must be called while holding the write lock.
+func (p *LikesProjection) applyOnce(logEvent entity.DecisionLogEvent, previousID int64) {
+	if _, ok := p.applied[logEvent.ID]; ok {
+		return
+	}
+
+	now := p.now()
+	for missingID := previousID + 1; missingID < logEvent.ID; missingID++ {
+		if _, ok := p.applied[missingID]; ok {
+			continue
+		}
+
+		if _, ok := p.gaps[missingID]; !ok {
+			p.gaps[missingID] = now
+		}
+	}
+
+	delete(p.gaps, logEvent.ID)
+	p.applied[logEvent.ID] = struct{}{}
+
+	p.apply(logEvent)
+}
+
+// Moves the watermark over the applied events and the gaps that timed out, which become skipped. Must be called while
+// holding the write lock.
+func (p *LikesProjection) advanceWatermark() {
+	now := p.now()
+
+	for {
+		nextID := p.watermark + 1
+
+		if _, ok := p.applied[nextID]; ok {
+			delete(p.applied, nextID)
+		} else if missedAt, ok := p.gaps[nextID]; ok && now.Sub(missedAt) >= p.config.GapTimeout {
+			delete(p.gaps, nextID)
+			p.skipped[nextID] = struct{}{}
+		} else {
+			return
+		}
+
+		p.watermark = nextID
+	}
+}
+
+// Runs the projectors, must be called while holding the write lock
+func (p *LikesProjection) apply(logEvent entity.DecisionLogEvent) {
+	if logEvent.IsUserEvent() {
+		p.inactive.apply(logEvent)
+		p.blocks.apply(logEvent)
+
+		return
+	}
+
+	if logEvent.Type != entity.DecisionLogEventMatchCreated {
+		var previous *entity.Decision
+		if like, ok := p.likers.like(logEvent.AuthorID, logEvent.RecipientID); ok {
+			previous = &like
+		}
+
+		p.likers.apply(logEvent)
+		p.counters.apply(logEvent, previous)
+	}
+
+	p.matches.apply(logEvent)
+}
+
+func (p *LikesProjection) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	return p.pageOfLikers(ctx, recipientID, query, cursor, limit, false)
+}
+
+func (p *LikesProjection) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	return p.pageOfLikers(ctx, recipientID, query, cursor, limit, true)
+}
+
+func (p *LikesProjection) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	counts := maps.Clone(p.counters.countsOf(profileID))
+	if counts == nil {
+		counts = map[entity.DecisionType]int64{}
+	}
+
+	// The likes of the hidden users are counted by the projection, they are subtracted here
+	for authorID, decision := range p.likers.likersOf(profileID) {
+		if !p.isHidden(profileID, authorID) {
+			continue
+		}
+
+		counts[decision.Type]--
+		if counts[decision.Type] <= 0 {
+			delete(counts, decision.Type)
+		}
+	}
+
+	return counts, nil
+}
+
+func (p *LikesProjection) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Like the repositories, the match is hidden when one of the users is inactive or has blocked the other
+	return p.matches.isMatch(userID, recipientUserID) && !p.isHidden(userID, recipientUserID) && !p.inactive.isInactive(userID), nil
+}
+
+func (p *LikesProjection) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) ([]entity.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	matches := make([]entity.Match, 0, len(p.matches.matchesOf(userID)))
+
+	for otherUserID := range p.matches.matchesOf(userID) {
+		if p.isHidden(userID, otherUserID) {
+			continue
+		}
+
+		mine, _ := p.likers.like(userID, otherUserID)
+		theirs, _ := p.likers.like(otherUserID, userID)
+
+		match := entity.Match{
+			UserID:    otherUserID,
+			MatchedAt: latest(mine.UpdatedAt, theirs.UpdatedAt),
+		}
+
+		if cursor != nil && !isBefore(match.MatchedAt, match.UserID, cursor.MatchedAt, cursor.UserID) {
+			continue
+		}
+
+		matches = append(matches, match)
+	}
+	p.mu.RUnlock()
+
+	// Most recent match first, same order as the repositories
+	sort.Slice(matches, func(i, j int) bool {
+		return isBefore(matches[j].MatchedAt, matches[j].UserID, matches[i].MatchedAt, matches[i].UserID)
+	})
+
+	return matches[:min(limit, len(matches))], nil
+}
+
+// Returns the likes received by the recipient of the query types, in the query order, starting after the cursor.
+// When onlyNew is true the likers that have been liked back by the recipient are excluded.
+func (p *LikesProjection) pageOfLikers(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int, onlyNew bool) ([]entity.Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	types := query.Types
+	if len(types) == 0 {
+		types = entity.LikeDecisionTypes
+	}
+
+	p.mu.RLock()
+	likers := make([]entity.Decision, 0)
+
+	for authorID, decision := range p.likers.likersOf(recipientID) {
+		if !slices.Contains(types, decision.Type) || p.isHidden(recipientID, authorID) {
+			continue
+		}
+
+		if _, likedBack := p.likers.like(recipientID, authorID); onlyNew && likedBack {
+			continue
+		}
+
+		if cursor != nil && !isLikerBefore(query.Order, decision, cursor.Rank, cursor.UpdatedAt, cursor.ID) {
+			continue
+		}
+
+		likers = append(likers, decision)
+	}
+	p.mu.RUnlock()
+
+	// Same order as the repositories
+	sort.Slice(likers, func(i, j int) bool {
+		return isLikerBefore(query.Order, likers[j], likers[i].Type.Rank(), likers[i].UpdatedAt, likers[i].ID)
+	})
+
+	return likers[:min(limit, len(likers))], nil
+}
+
+// True when the likes of the user are hidden from the viewer: the user is inactive or one of them blocked the other.
+// Must be called while holding the read lock.
+func (p *LikesProjection) isHidden(viewerID entity.UserID, userID entity.UserID) bool {
+	return p.inactive.isInactive(userID) || p.blocks.isBlocked(viewerID, userID)
+}
@@ -0,0 +1,297 @@
+package projection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"github.com/lokker96/grpc_project/domain/repository/repositorytest"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/memory"
+	"github.com/magiconair/properties/assert"
+)
+
+// Repository whose read side is served by the projection, like the explorer server does with the projections read model.
+// The projection catches up before every read instead of in the background, so the reads see the writes before them.
+type projectedRepository struct {
+	repository.ExplorerRepository
+	projection *LikesProjection
+}
+
+func (r projectedRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) error {
+	if err := r.ExplorerRepository.EraseUser(ctx, userID, erasure); err != nil {
+		return err
+	}
+
+	r.projection.Forget(userID)
+
+	return nil
+}
+
+func (r projectedRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	if err := r.projection.catchUp(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.projection.ListLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r projectedRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	if err := r.projection.catchUp(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.projection.ListNewLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r projectedRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error) {
+	if err := r.projection.catchUp(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.projection.GetLikesCountsByProfileId(ctx, profileID)
+}
+
+func (r projectedRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
+	if err := r.projection.catchUp(ctx); err != nil {
+		return false, err
+	}
+
+	return r.projection.FindMutualLike(ctx, userID, recipientUserID)
+}
+
+func (r projectedRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) ([]entity.Match, error) {
+	if err := r.projection.catchUp(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.projection.ListMatchesForUserId(ctx, userID, cursor, limit)
+}
+
+// The projection reads the same likes and matches as the repository
+func Test_LikesProjectionConformance(t *testing.T) {
+	repositorytest.RunExplorerRepositoryTests(t, func(t *testing.T) repository.ExplorerRepository {
+		explorerRepository := memory.NewExplorerRepository()
+
+		// A small batch makes the catch ups page through the log
+		config := DefaultLikesProjectionConfig()
+		config.BatchSize = 2
+
+		return projectedRepository{
+			ExplorerRepository: explorerRepository,
+			projection:         NewLikesProjection(explorerRepository, config),
+		}
+	})
+}
+
+// Repository hiding some events of the log, as if their transactions had not committed yet
+type uncommittedLogRepository struct {
+	repository.ExplorerRepository
+	uncommitted map[int64]bool
+}
+
+func (r *uncommittedLogRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) ([]entity.DecisionLogEvent, error) {
+	events, err := r.ExplorerRepository.ReadDecisionLog(ctx, afterID, missingIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make([]entity.DecisionLogEvent, 0, len(events))
+	for _, logEvent := range events {
+		if !r.uncommitted[logEvent.ID] {
+			committed = append(committed, logEvent)
+		}
+	}
+
+	return committed, nil
+}
+
+func createUsers(t *testing.T, explorerRepository repository.ExplorerRepository, count int) []entity.UserID {
+	userIDs := make([]entity.UserID, 0, count)
+
+	for i := 0; i < count; i++ {
+		user := &entity.User{}
+		if err := explorerRepository.CreateUser(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs
+}
+
+func upsert(t *testing.T, explorerRepository repository.ExplorerRepository, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) {
+	if _, _, err := explorerRepository.UpsertDecision(context.Background(), authorID, recipientID, decisionType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Catches up with the log and returns the likers of the recipient
+func likerIDs(t *testing.T, likesProjection *LikesProjection, recipientID entity.UserID) []entity.UserID {
+	if err := likesProjection.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	likers, err := likesProjection.ListLikersForRecipientId(context.Background(), recipientID, repository.LikersQuery{Order: repository.LikersOrderMostRecent}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]entity.UserID, 0, len(likers))
+	for _, liker := range likers {
+		ids = append(ids, liker.AuthorID)
+	}
+
+	return ids
+}
+
+func Test_LikesProjection_CommittedOutOfOrder(t *testing.T) {
+	explorerRepository := &uncommittedLogRepository{
+		ExplorerRepository: memory.NewExplorerRepository(),
+		uncommitted:        map[int64]bool{1: true},
+	}
+
+	users := createUsers(t, explorerRepository, 3)
+
+	// Event 1 is the like of users[0], event 2 the like of users[2]
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[1], entity.DecisionTypeLike)
+
+	now := time.Now()
+	likesProjection := NewLikesProjection(explorerRepository, DefaultLikesProjectionConfig())
+	likesProjection.now = func() time.Time { return now }
+
+	// The event committed later is applied once it shows up, the watermark waits for it
+	assert.Equal(t, likerIDs(t, likesProjection, users[1]), []entity.UserID{users[2]})
+	assert.Equal(t, likesProjection.watermark, int64(0))
+
+	delete(explorerRepository.uncommitted, 1)
+
+	assert.Equal(t, likerIDs(t, likesProjection, users[1]), []entity.UserID{users[2], users[0]})
+	assert.Equal(t, likesProjection.watermark, int64(2))
+
+	// Event 3, the like of users[0], commits too late and is skipped once the gap timed out.
+	// Events 4 and 5 are the like of users[1] and the match it makes with users[2].
+	explorerRepository.uncommitted[3] = true
+
+	upsert(t, explorerRepository, users[0], users[2], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[2], entity.DecisionTypeLike)
+
+	assert.Equal(t, likerIDs(t, likesProjection, users[2]), []entity.UserID{users[1]})
+	assert.Equal(t, likesProjection.watermark, int64(2))
+
+	now = now.Add(DefaultLikesProjectionConfig().GapTimeout)
+
+	assert.Equal(t, likerIDs(t, likesProjection, users[2]), []entity.UserID{users[1]})
+	assert.Equal(t, likesProjection.watermark, int64(5))
+	assert.Equal(t, len(likesProjection.gaps), 0)
+	assert.Equal(t, len(likesProjection.applied), 0)
+	assert.Equal(t, likesProjection.skipped, map[int64]struct{}{3: {}})
+
+	// The skipped event is still read and applied once it commits
+	delete(explorerRepository.uncommitted, 3)
+
+	assert.Equal(t, likerIDs(t, likesProjection, users[2]), []entity.UserID{users[1], users[0]})
+	assert.Equal(t, likesProjection.watermark, int64(5))
+	assert.Equal(t, len(likesProjection.skipped), 0)
+}
+
+func Test_LikesProjection_Rebuild(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 3)
+
+	upsert(t, explorerRepository, users[0], users[1], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeSuperLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypePass)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+
+	likesProjection := NewLikesProjection(explorerRepository, DefaultLikesProjectionConfig())
+
+	// The projection is dropped from memory, rebuilding it replays the whole log
+	likesProjection.Forget(users[0])
+	if err := likesProjection.Rebuild(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := likesProjection.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts, map[entity.DecisionType]int64{entity.DecisionTypeLike: 1, entity.DecisionTypeSuperLike: 1})
+
+	matches, err := likesProjection.ListMatchesForUserId(ctx, users[0], nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].UserID, users[1])
+
+	// The likes of the forgotten user are dropped from every read model until the next rebuild
+	likesProjection.Forget(users[1])
+
+	counts, err = likesProjection.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts, map[entity.DecisionType]int64{entity.DecisionTypeLike: 1})
+
+	matched, err := likesProjection.FindMutualLike(ctx, users[0], users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, matched, false)
+}
+
+func Test_LikesProjection_CatchesUpInTheBackground(t *testing.T) {
+	explorerRepository := memory.NewExplorerRepository()
+	ctx := context.Background()
+	users := createUsers(t, explorerRepository, 3)
+
+	config := DefaultLikesProjectionConfig()
+	config.CatchUpInterval = time.Millisecond
+
+	likesProjection := NewLikesProjection(explorerRepository, config)
+	likesProjection.Start()
+	defer likesProjection.Stop()
+
+	// The like of users[2] is hidden once users[0] blocks it, the 3 events are read without any read asking for them
+	upsert(t, explorerRepository, users[1], users[0], entity.DecisionTypeLike)
+	upsert(t, explorerRepository, users[2], users[0], entity.DecisionTypeLike)
+
+	if err := explorerRepository.BlockUser(ctx, users[0], users[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		likesProjection.mu.RLock()
+		caughtUp := likesProjection.watermark == 3
+		likesProjection.mu.RUnlock()
+
+		if caughtUp {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("the projection hasn't caught up with the log")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	counts, err := likesProjection.GetLikesCountsByProfileId(ctx, users[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, counts, map[entity.DecisionType]int64{entity.DecisionTypeLike: 1})
+	assert.Equal(t, likerIDs(t, likesProjection, users[0]), []entity.UserID{users[1]})
+}
@@ -0,0 +1,213 @@
+package projection
+
+import (
+	"github.com/lokker96/grpc_project/domain/entity"
+)
+
+// Projects the decision log into the likes received by every recipient, the likers are keyed by author
+type likerLists struct {
+	byRecipient map[entity.UserID]map[entity.UserID]entity.Decision
+}
+
+func newLikerLists() *likerLists {
+	return &likerLists{byRecipient: map[entity.UserID]map[entity.UserID]entity.Decision{}}
+}
+
+// Returns the like or super like of the author on the recipient
+func (l *likerLists) like(authorID entity.UserID, recipientID entity.UserID) (entity.Decision, bool) {
+	decision, ok := l.byRecipient[recipientID][authorID]
+
+	return decision, ok
+}
+
+func (l *likerLists) likersOf(recipientID entity.UserID) map[entity.UserID]entity.Decision {
+	return l.byRecipient[recipientID]
+}
+
+func (l *likerLists) apply(logEvent entity.DecisionLogEvent) {
+	decision := logEvent.Decision()
+	if decision == nil || !decision.IsLike() {
+		delete(l.byRecipient[logEvent.RecipientID], logEvent.AuthorID)
+		if len(l.byRecipient[logEvent.RecipientID]) == 0 {
+			delete(l.byRecipient, logEvent.RecipientID)
+		}
+
+		return
+	}
+
+	likers, ok := l.byRecipient[decision.RecipientID]
+	if !ok {
+		likers = map[entity.UserID]entity.Decision{}
+		l.byRecipient[decision.RecipientID] = likers
+	}
+
+	likers[decision.AuthorID] = *decision
+}
+
+// Projects the decision log into the number of likes received by every recipient, by type.
+// The counts include the likes hidden from the recipient, they are subtracted when read.
+type likeCounters struct {
+	byRecipient map[entity.UserID]map[entity.DecisionType]int64
+}
+
+func newLikeCounters() *likeCounters {
+	return &likeCounters{byRecipient: map[entity.UserID]map[entity.DecisionType]int64{}}
+}
+
+func (c *likeCounters) countsOf(recipientID entity.UserID) map[entity.DecisionType]int64 {
+	return c.byRecipient[recipientID]
+}
+
+// Moves the count from the previous like of the author, if any, to the type of the decision after the event
+func (c *likeCounters) apply(logEvent entity.DecisionLogEvent, previous *entity.Decision) {
+	if previous != nil {
+		counts := c.byRecipient[previous.RecipientID]
+
+		counts[previous.Type]--
+		if counts[previous.Type] <= 0 {
+			delete(counts, previous.Type)
+		}
+
+		if len(counts) == 0 {
+			delete(c.byRecipient, previous.RecipientID)
+		}
+	}
+
+	decision := logEvent.Decision()
+	if decision == nil || !decision.IsLike() {
+		return
+	}
+
+	counts, ok := c.byRecipient[decision.RecipientID]
+	if !ok {
+		counts = map[entity.DecisionType]int64{}
+		c.byRecipient[decision.RecipientID] = counts
+	}
+
+	counts[decision.Type]++
+}
+
+// Projects the decision log into the matches of every user, a match is recorded on both sides.
+// It is made by the match events and undone by the first decision of the pair that isn't a like anymore.
+type matchTable struct {
+	byUser map[entity.UserID]map[entity.UserID]struct{}
+}
+
+func newMatchTable() *matchTable {
+	return &matchTable{byUser: map[entity.UserID]map[entity.UserID]struct{}{}}
+}
+
+func (m *matchTable) matchesOf(userID entity.UserID) map[entity.UserID]struct{} {
+	return m.byUser[userID]
+}
+
+func (m *matchTable) isMatch(userID entity.UserID, otherUserID entity.UserID) bool {
+	_, ok := m.byUser[userID][otherUserID]
+
+	return ok
+}
+
+func (m *matchTable) apply(logEvent entity.DecisionLogEvent) {
+	if logEvent.Type == entity.DecisionLogEventMatchCreated {
+		m.add(logEvent.AuthorID, logEvent.RecipientID)
+		m.add(logEvent.RecipientID, logEvent.AuthorID)
+
+		return
+	}
+
+	if decision := logEvent.Decision(); decision == nil || !decision.IsLike() {
+		m.remove(logEvent.AuthorID, logEvent.RecipientID)
+		m.remove(logEvent.RecipientID, logEvent.AuthorID)
+	}
+}
+
+func (m *matchTable) add(userID entity.UserID, otherUserID entity.UserID) {
+	matches, ok := m.byUser[userID]
+	if !ok {
+		matches = map[entity.UserID]struct{}{}
+		m.byUser[userID] = matches
+	}
+
+	matches[otherUserID] = struct{}{}
+}
+
+func (m *matchTable) remove(userID entity.UserID, otherUserID entity.UserID) {
+	delete(m.byUser[userID], otherUserID)
+	if len(m.byUser[userID]) == 0 {
+		delete(m.byUser, userID)
+	}
+}
+
+// Projects the decision log into the deactivated and deleted users, whose likes are hidden from everyone.
+// An account is never reactivated, a user only leaves the set once it is forgotten.
+type inactiveUsers struct {
+	byID map[entity.UserID]struct{}
+}
+
+func newInactiveUsers() *inactiveUsers {
+	return &inactiveUsers{byID: map[entity.UserID]struct{}{}}
+}
+
+func (u *inactiveUsers) isInactive(userID entity.UserID) bool {
+	_, ok := u.byID[userID]
+
+	return ok
+}
+
+func (u *inactiveUsers) apply(logEvent entity.DecisionLogEvent) {
+	if logEvent.Type == entity.DecisionLogEventUserDeactivated || logEvent.Type == entity.DecisionLogEventUserDeleted {
+		u.byID[logEvent.AuthorID] = struct{}{}
+	}
+}
+
+func (u *inactiveUsers) forget(userID entity.UserID) {
+	delete(u.byID, userID)
+}
+
+// Projects the decision log into the blocks, keyed by blocker. A block hides the likes in both directions.
+type blockTable struct {
+	byBlocker map[entity.UserID]map[entity.UserID]struct{}
+}
+
+func newBlockTable() *blockTable {
+	return &blockTable{byBlocker: map[entity.UserID]map[entity.UserID]struct{}{}}
+}
+
+// True if any of the users has blocked the other
+func (b *blockTable) isBlocked(userID entity.UserID, otherUserID entity.UserID) bool {
+	_, blocked := b.byBlocker[userID][otherUserID]
+	_, blockedBy := b.byBlocker[otherUserID][userID]
+
+	return blocked || blockedBy
+}
+
+func (b *blockTable) apply(logEvent entity.DecisionLogEvent) {
+	switch logEvent.Type {
+	case entity.DecisionLogEventUserBlocked:
+		blocked, ok := b.byBlocker[logEvent.AuthorID]
+		if !ok {
+			blocked = map[entity.UserID]struct{}{}
+			b.byBlocker[logEvent.AuthorID] = blocked
+		}
+
+		blocked[logEvent.RecipientID] = struct{}{}
+	case entity.DecisionLogEventUserUnblocked:
+		b.remove(logEvent.AuthorID, logEvent.RecipientID)
+	}
+}
+
+// Drops the blocks made or received by the user
+func (b *blockTable) forget(userID entity.UserID) {
+	delete(b.byBlocker, userID)
+
+	for blockerID := range b.byBlocker {
+		b.remove(blockerID, userID)
+	}
+}
+
+func (b *blockTable) remove(blockerID entity.UserID, blockedID entity.UserID) {
+	delete(b.byBlocker[blockerID], blockedID)
+	if len(b.byBlocker[blockerID]) == 0 {
+		delete(b.byBlocker, blockerID)
+	}
+}
@@ -0,0 +1,508 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: explore/v2/explore-service.proto
+
+// Version 2 of the explore API, the user IDs are positive int64 instead of numeric strings.
+// A user ID of 0 or less is rejected with INVALID_ARGUMENT.
+
+package explorev2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExploreService_ListLikedYou_FullMethodName        = "/explore.v2.ExploreService/ListLikedYou"
+	ExploreService_ListNewLikedYou_FullMethodName     = "/explore.v2.ExploreService/ListNewLikedYou"
+	ExploreService_CountLikedYou_FullMethodName       = "/explore.v2.ExploreService/CountLikedYou"
+	ExploreService_PutDecision_FullMethodName         = "/explore.v2.ExploreService/PutDecision"
+	ExploreService_UndoLastDecision_FullMethodName    = "/explore.v2.ExploreService/UndoLastDecision"
+	ExploreService_ListMatches_FullMethodName         = "/explore.v2.ExploreService/ListMatches"
+	ExploreService_WatchLikes_FullMethodName          = "/explore.v2.ExploreService/WatchLikes"
+	ExploreService_BlockUser_FullMethodName           = "/explore.v2.ExploreService/BlockUser"
+	ExploreService_UnblockUser_FullMethodName         = "/explore.v2.ExploreService/UnblockUser"
+	ExploreService_ReportUser_FullMethodName          = "/explore.v2.ExploreService/ReportUser"
+	ExploreService_ListDecisionHistory_FullMethodName = "/explore.v2.ExploreService/ListDecisionHistory"
+)
+
+// ExploreServiceClient is the client API for ExploreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExploreServiceClient interface {
+	ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
+	ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
+	CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
+	PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error)
+	UndoLastDecision(ctx context.Context, in *UndoLastDecisionRequest, opts ...grpc.CallOption) (*UndoLastDecisionResponse, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	WatchLikes(ctx context.Context, in *WatchLikesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LikeEvent], error)
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	ReportUser(ctx context.Context, in *ReportUserRequest, opts ...grpc.CallOption) (*ReportUserResponse, error)
+	ListDecisionHistory(ctx context.Context, in *ListDecisionHistoryRequest, opts ...grpc.CallOption) (*ListDecisionHistoryResponse, error)
+}
+
+type exploreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExploreServiceClient(cc grpc.ClientConnInterface) ExploreServiceClient {
+	return &exploreServiceClient{cc}
+}
+
+func (c *exploreServiceClient) ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListNewLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_PutDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UndoLastDecision(ctx context.Context, in *UndoLastDecisionRequest, opts ...grpc.CallOption) (*UndoLastDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndoLastDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UndoLastDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) WatchLikes(ctx context.Context, in *WatchLikesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LikeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[0], ExploreService_WatchLikes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLikesRequest, LikeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikesClient = grpc.ServerStreamingClient[LikeEvent]
+
+func (c *exploreServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ReportUser(ctx context.Context, in *ReportUserRequest, opts ...grpc.CallOption) (*ReportUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ReportUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListDecisionHistory(ctx context.Context, in *ListDecisionHistoryRequest, opts ...grpc.CallOption) (*ListDecisionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDecisionHistoryResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListDecisionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExploreServiceServer is the server API for ExploreService service.
+// All implementations must embed UnimplementedExploreServiceServer
+// for forward compatibility.
+type ExploreServiceServer interface {
+	ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
+	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
+	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
+	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+	UndoLastDecision(context.Context, *UndoLastDecisionRequest) (*UndoLastDecisionResponse, error)
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	WatchLikes(*WatchLikesRequest, grpc.ServerStreamingServer[LikeEvent]) error
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	ReportUser(context.Context, *ReportUserRequest) (*ReportUserResponse, error)
+	ListDecisionHistory(context.Context, *ListDecisionHistoryRequest) (*ListDecisionHistoryResponse, error)
+	mustEmbedUnimplementedExploreServiceServer()
+}
+
+// UnimplementedExploreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExploreServiceServer struct{}
+
+func (UnimplementedExploreServiceServer) ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNewLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) UndoLastDecision(context.Context, *UndoLastDecisionRequest) (*UndoLastDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoLastDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) WatchLikes(*WatchLikesRequest, grpc.ServerStreamingServer[LikeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLikes not implemented")
+}
+func (UnimplementedExploreServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ReportUser(context.Context, *ReportUserRequest) (*ReportUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ListDecisionHistory(context.Context, *ListDecisionHistoryRequest) (*ListDecisionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDecisionHistory not implemented")
+}
+func (UnimplementedExploreServiceServer) mustEmbedUnimplementedExploreServiceServer() {}
+func (UnimplementedExploreServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeExploreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExploreServiceServer will
+// result in compilation errors.
+type UnsafeExploreServiceServer interface {
+	mustEmbedUnimplementedExploreServiceServer()
+}
+
+func RegisterExploreServiceServer(s grpc.ServiceRegistrar, srv ExploreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExploreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExploreService_ServiceDesc, srv)
+}
+
+func _ExploreService_ListLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListLikedYou(ctx, req.(*ListLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListNewLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListNewLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, req.(*ListLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_CountLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountLikedYou(ctx, req.(*CountLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).PutDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_PutDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).PutDecision(ctx, req.(*PutDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UndoLastDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoLastDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UndoLastDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UndoLastDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UndoLastDecision(ctx, req.(*UndoLastDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_WatchLikes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLikesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).WatchLikes(m, &grpc.GenericServerStream[WatchLikesRequest, LikeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikesServer = grpc.ServerStreamingServer[LikeEvent]
+
+func _ExploreService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ReportUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ReportUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ReportUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ReportUser(ctx, req.(*ReportUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListDecisionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDecisionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListDecisionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListDecisionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListDecisionHistory(ctx, req.(*ListDecisionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExploreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "explore.v2.ExploreService",
+	HandlerType: (*ExploreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListLikedYou",
+			Handler:    _ExploreService_ListLikedYou_Handler,
+		},
+		{
+			MethodName: "ListNewLikedYou",
+			Handler:    _ExploreService_ListNewLikedYou_Handler,
+		},
+		{
+			MethodName: "CountLikedYou",
+			Handler:    _ExploreService_CountLikedYou_Handler,
+		},
+		{
+			MethodName: "PutDecision",
+			Handler:    _ExploreService_PutDecision_Handler,
+		},
+		{
+			MethodName: "UndoLastDecision",
+			Handler:    _ExploreService_UndoLastDecision_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _ExploreService_ListMatches_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _ExploreService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _ExploreService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ReportUser",
+			Handler:    _ExploreService_ReportUser_Handler,
+		},
+		{
+			MethodName: "ListDecisionHistory",
+			Handler:    _ExploreService_ListDecisionHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLikes",
+			Handler:       _ExploreService_WatchLikes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "explore/v2/explore-service.proto",
+}
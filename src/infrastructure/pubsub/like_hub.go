@@ -0,0 +1,334 @@
+package pubsub
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/event"
+)
+
+type LikeHubConfig struct {
+	SubscriberBufferSize int           // Events buffered per subscriber, a subscriber with a full buffer is dropped as a slow consumer
+	HistorySize          int           // Events retained per recipient so the streams can be resumed
+	HistoryTTL           time.Duration // How long the events are retained
+}
+
+func DefaultLikeHubConfig() LikeHubConfig {
+	return LikeHubConfig{
+		SubscriberBufferSize: 64,
+		HistorySize:          100,
+		HistoryTTL:           10 * time.Minute,
+	}
+}
+
+// Published event with its position in the hub
+type retainedEvent struct {
+	sequence uint64
+	event    event.LikeEvent
+}
+
+// Subscribers and recent events of a recipient
+type topic struct {
+	history     []retainedEvent
+	prunedUpTo  uint64 // Events up to this sequence may have existed and are not retained anymore
+	subscribers map[*likeSubscription]struct{}
+}
+
+// The like hub is an in-process pub/sub: every recipient has a topic with many subscribers.
+// Events are numbered with a hub wide sequence and the last events of each recipient are retained,
+// so a subscriber dropped for being too slow or a client that reconnects can resume from its cursor.
+// Cursors contain the hub epoch, cursors of a previous process are rejected as expired.
+type LikeHub struct {
+	mu       sync.Mutex
+	config   LikeHubConfig
+	epoch    int64
+	sequence uint64
+	topics   map[entity.UserID]*topic
+	closed   bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func NewLikeHub(config LikeHubConfig) *LikeHub {
+	hub := &LikeHub{
+		config: config,
+		epoch:  time.Now().UnixNano(),
+		topics: map[entity.UserID]*topic{},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go hub.pruneLoop()
+
+	return hub
+}
+
+func (h *LikeHub) Publish(ctx context.Context, likeEvent event.LikeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.sequence++
+	likeEvent.Cursor = h.encodeCursor(h.sequence)
+
+	t := h.topic(likeEvent.RecipientID)
+
+	t.history = append(t.history, retainedEvent{sequence: h.sequence, event: likeEvent})
+	h.pruneHistory(t, time.Now())
+
+	for subscriber := range t.subscribers {
+		select {
+		case subscriber.events <- likeEvent:
+		default:
+			// Never block the publisher, the subscriber can resume from the last event it received
+			h.drop(subscriber, domainError.NewSlowConsumerErr())
+		}
+	}
+}
+
+func (h *LikeHub) Subscribe(ctx context.Context, recipientID entity.UserID, resumeCursor string) (event.LikeSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, domainError.NewUnavailableErr("like hub is closed")
+	}
+
+	t := h.topic(recipientID)
+
+	replay := make([]event.LikeEvent, 0)
+
+	if resumeCursor != "" {
+		afterSequence, err := h.decodeCursor(resumeCursor)
+		if err != nil {
+			return nil, err
+		}
+
+		// Some events after the cursor may have been pruned, the client has to reload its state
+		if afterSequence < t.prunedUpTo {
+			return nil, domainError.NewResumeCursorExpiredErr()
+		}
+
+		for _, retained := range t.history {
+			if retained.sequence > afterSequence {
+				replay = append(replay, retained.event)
+			}
+		}
+	}
+
+	subscriber := &likeSubscription{
+		hub:         h,
+		recipientID: recipientID,
+		events:      make(chan event.LikeEvent, h.config.SubscriberBufferSize+len(replay)),
+	}
+
+	for _, replayed := range replay {
+		subscriber.events <- replayed
+	}
+
+	t.subscribers[subscriber] = struct{}{}
+
+	// The subscription ends with the context of the subscriber
+	subscriber.stopAfterFunc = context.AfterFunc(ctx, subscriber.Close)
+
+	return subscriber, nil
+}
+
+// Called once the user has been erased, nothing about the user is kept in memory after it
+func (h *LikeHub) Forget(userID entity.UserID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// The topic of the user goes away with its history, the cursors of the user are expired from now on
+	if t, ok := h.topics[userID]; ok {
+		for subscriber := range t.subscribers {
+			h.drop(subscriber, domainError.NewUserNotFoundErr())
+		}
+
+		delete(h.topics, userID)
+	}
+
+	// The events about the user in the other topics are not replayed anymore
+	for _, t := range h.topics {
+		history := t.history[:0]
+
+		for _, retained := range t.history {
+			if retained.event.ActorID != userID {
+				history = append(history, retained)
+			}
+		}
+
+		t.history = history
+	}
+}
+
+// Ends all the subscriptions and stops the background pruning, nothing can be published afterwards
+func (h *LikeHub) Close() {
+	h.mu.Lock()
+
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+
+	h.closed = true
+
+	for _, t := range h.topics {
+		for subscriber := range t.subscribers {
+			h.drop(subscriber, domainError.NewUnavailableErr("like hub is closed"))
+		}
+	}
+
+	h.mu.Unlock()
+
+	close(h.stop)
+	<-h.done
+}
+
+// Returns the topic of the recipient, creating it if needed. Must be called while holding the lock.
+func (h *LikeHub) topic(recipientID entity.UserID) *topic {
+	t, ok := h.topics[recipientID]
+	if !ok {
+		t = &topic{
+			// Older events of the recipient may have been pruned together with its previous topic
+			prunedUpTo:  h.sequence,
+			subscribers: map[*likeSubscription]struct{}{},
+		}
+		h.topics[recipientID] = t
+	}
+
+	return t
+}
+
+// Removes the events over the history size or older than the TTL. Must be called while holding the lock.
+func (h *LikeHub) pruneHistory(t *topic, now time.Time) {
+	keepFrom := 0
+
+	if len(t.history) > h.config.HistorySize {
+		keepFrom = len(t.history) - h.config.HistorySize
+	}
+
+	for keepFrom < len(t.history) && now.Sub(t.history[keepFrom].event.OccurredAt) > h.config.HistoryTTL {
+		keepFrom++
+	}
+
+	if keepFrom > 0 {
+		t.prunedUpTo = t.history[keepFrom-1].sequence
+		t.history = append([]retainedEvent(nil), t.history[keepFrom:]...)
+	}
+}
+
+// Periodically prunes the expired events and removes the topics that are not used anymore
+func (h *LikeHub) pruneLoop() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stop:
+			return
+		case now := <-ticker.C:
+			h.mu.Lock()
+
+			for recipientID, t := range h.topics {
+				h.pruneHistory(t, now)
+
+				if len(t.history) == 0 && len(t.subscribers) == 0 {
+					delete(h.topics, recipientID)
+				}
+			}
+
+			h.mu.Unlock()
+		}
+	}
+}
+
+// Ends a subscription, must be called while holding the lock
+func (h *LikeHub) drop(subscriber *likeSubscription, err error) {
+	if subscriber.closed {
+		return
+	}
+
+	subscriber.closed = true
+	subscriber.err = err
+	close(subscriber.events)
+
+	if subscriber.stopAfterFunc != nil {
+		subscriber.stopAfterFunc()
+	}
+
+	if t, ok := h.topics[subscriber.recipientID]; ok {
+		delete(t.subscribers, subscriber)
+	}
+}
+
+func (h *LikeHub) encodeCursor(sequence uint64) string {
+	return strconv.FormatInt(h.epoch, 36) + "." + strconv.FormatUint(sequence, 36)
+}
+
+func (h *LikeHub) decodeCursor(cursor string) (uint64, error) {
+	invalidErr := domainError.NewInvalidArgumentErr("resume_token", "is not a valid resume token")
+
+	epochPart, sequencePart, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return 0, invalidErr
+	}
+
+	epoch, err := strconv.ParseInt(epochPart, 36, 64)
+	if err != nil {
+		return 0, invalidErr
+	}
+
+	sequence, err := strconv.ParseUint(sequencePart, 36, 64)
+	if err != nil {
+		return 0, invalidErr
+	}
+
+	// The cursor has been created by a previous process, its events are gone
+	if epoch != h.epoch {
+		return 0, domainError.NewResumeCursorExpiredErr()
+	}
+
+	if sequence > h.sequence {
+		return 0, invalidErr
+	}
+
+	return sequence, nil
+}
+
+type likeSubscription struct {
+	hub           *LikeHub
+	recipientID   entity.UserID
+	events        chan event.LikeEvent
+	stopAfterFunc func() bool
+	closed        bool  // Guarded by the hub lock
+	err           error // Guarded by the hub lock
+}
+
+func (s *likeSubscription) Events() <-chan event.LikeEvent {
+	return s.events
+}
+
+func (s *likeSubscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	return s.err
+}
+
+func (s *likeSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.drop(s, nil)
+}
@@ -0,0 +1,268 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	domainError "github.com/lokker96/grpc_project/domain/error"
+	"github.com/lokker96/grpc_project/domain/event"
+	"github.com/magiconair/properties/assert"
+)
+
+func newLike(actorID entity.UserID, recipientID entity.UserID) event.LikeEvent {
+	return event.LikeEvent{
+		Type:        event.LikeEventTypeLike,
+		ActorID:     actorID,
+		RecipientID: recipientID,
+		OccurredAt:  time.Now(),
+	}
+}
+
+// Reads the events already buffered in the subscription
+func buffered(subscription event.LikeSubscription) []event.LikeEvent {
+	events := make([]event.LikeEvent, 0)
+
+	for {
+		select {
+		case likeEvent, ok := <-subscription.Events():
+			if !ok {
+				return events
+			}
+
+			events = append(events, likeEvent)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_LikeHub_ManySubscribersPerUser(t *testing.T) {
+	hub := NewLikeHub(DefaultLikeHubConfig())
+	defer hub.Close()
+
+	ctx := context.Background()
+
+	first, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := hub.Subscribe(ctx, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Publish(ctx, newLike(3, 1))
+
+	for _, subscription := range []event.LikeSubscription{first, second} {
+		events := buffered(subscription)
+
+		assert.Equal(t, len(events), 1)
+		assert.Equal(t, events[0].ActorID, entity.UserID(3))
+
+		if events[0].Cursor == "" {
+			t.Fatal("expected the hub to set the cursor")
+		}
+	}
+
+	assert.Equal(t, len(buffered(other)), 0)
+}
+
+func Test_LikeHub_SlowConsumerIsDroppedAndResumes(t *testing.T) {
+	config := DefaultLikeHubConfig()
+	config.SubscriberBufferSize = 2
+
+	hub := NewLikeHub(config)
+	defer hub.Close()
+
+	ctx := context.Background()
+
+	subscription, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The third event doesn't fit in the buffer
+	for actorID := entity.UserID(2); actorID <= 4; actorID++ {
+		hub.Publish(ctx, newLike(actorID, 1))
+	}
+
+	events := buffered(subscription)
+
+	assert.Equal(t, len(events), 2)
+
+	if !errors.As(subscription.Err(), &domainError.SlowConsumerErr{}) {
+		t.Fatalf("expected a slow consumer error, got %v", subscription.Err())
+	}
+
+	// Resuming from the last event received replays the missed one
+	resumed, err := hub.Subscribe(ctx, 1, events[1].Cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := buffered(resumed)
+
+	assert.Equal(t, len(replayed), 1)
+	assert.Equal(t, replayed[0].ActorID, entity.UserID(4))
+}
+
+func Test_LikeHub_ResumeCursors(t *testing.T) {
+	config := DefaultLikeHubConfig()
+	config.HistorySize = 2
+
+	hub := NewLikeHub(config)
+	defer hub.Close()
+
+	ctx := context.Background()
+
+	subscription, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for actorID := entity.UserID(2); actorID <= 4; actorID++ {
+		hub.Publish(ctx, newLike(actorID, 1))
+	}
+
+	events := buffered(subscription)
+	assert.Equal(t, len(events), 3)
+
+	// The first event has been pruned from the history, the events after it are still retained
+	resumed, err := hub.Subscribe(ctx, 1, events[0].Cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(buffered(resumed)), 2)
+
+	// The event before the first one may be missing
+	_, err = hub.Subscribe(ctx, 1, hub.encodeCursor(0))
+	if !errors.As(err, &domainError.ResumeCursorExpiredErr{}) {
+		t.Fatalf("expected a resume cursor expired error, got %v", err)
+	}
+
+	// Cursors of another hub are expired
+	otherHub := NewLikeHub(config)
+	defer otherHub.Close()
+
+	_, err = otherHub.Subscribe(ctx, 1, events[2].Cursor)
+	if !errors.As(err, &domainError.ResumeCursorExpiredErr{}) {
+		t.Fatalf("expected a resume cursor expired error, got %v", err)
+	}
+
+	_, err = hub.Subscribe(ctx, 1, "not a cursor")
+	if !errors.As(err, &domainError.InvalidArgumentErr{}) {
+		t.Fatalf("expected an invalid argument error, got %v", err)
+	}
+}
+
+func Test_LikeHub_SubscriptionEndsWithContext(t *testing.T) {
+	hub := NewLikeHub(DefaultLikeHubConfig())
+	defer hub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	subscription, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-subscription.Events():
+		assert.Equal(t, ok, false)
+	case <-time.After(time.Second):
+		t.Fatal("expected the subscription to end with its context")
+	}
+
+	assert.Equal(t, subscription.Err(), nil)
+}
+
+func Test_LikeHub_Close(t *testing.T) {
+	hub := NewLikeHub(DefaultLikeHubConfig())
+
+	ctx := context.Background()
+
+	subscription, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Close()
+
+	_, ok := <-subscription.Events()
+	assert.Equal(t, ok, false)
+
+	if !errors.As(subscription.Err(), &domainError.UnavailableErr{}) {
+		t.Fatalf("expected an unavailable error, got %v", subscription.Err())
+	}
+
+	_, err = hub.Subscribe(ctx, 1, "")
+	if !errors.As(err, &domainError.UnavailableErr{}) {
+		t.Fatalf("expected an unavailable error, got %v", err)
+	}
+
+	// Publishing after closing is ignored
+	hub.Publish(ctx, newLike(2, 1))
+}
+
+func Test_LikeHub_Forget(t *testing.T) {
+	hub := NewLikeHub(DefaultLikeHubConfig())
+	defer hub.Close()
+
+	ctx := context.Background()
+
+	erased, err := hub.Subscribe(ctx, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := hub.Subscribe(ctx, 3, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Publish(ctx, newLike(2, 1))
+	hub.Publish(ctx, newLike(1, 3))
+	hub.Publish(ctx, newLike(2, 3))
+
+	cursor := buffered(erased)[0].Cursor
+
+	hub.Forget(1)
+
+	// The subscriptions of the erased user end
+	_, ok := <-erased.Events()
+	assert.Equal(t, ok, false)
+
+	if !errors.As(erased.Err(), &domainError.UserNotFoundErr{}) {
+		t.Fatalf("expected a user not found error, got %v", erased.Err())
+	}
+
+	// Its cursors can't be resumed anymore
+	_, err = hub.Subscribe(ctx, 1, cursor)
+	if !errors.As(err, &domainError.ResumeCursorExpiredErr{}) {
+		t.Fatalf("expected a resume cursor expired error, got %v", err)
+	}
+
+	// The other users keep their subscriptions but its likes are not replayed to them anymore
+	assert.Equal(t, len(buffered(watcher)), 2)
+
+	resumed, err := hub.Subscribe(ctx, 3, hub.encodeCursor(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := buffered(resumed)
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].ActorID, entity.UserID(2))
+}
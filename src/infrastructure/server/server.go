@@ -0,0 +1,114 @@
+// Package server runs the gRPC server until it is asked to stop and then shuts it down gracefully
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/lokker96/grpc_project/infrastructure/healthcheck"
+	"google.golang.org/grpc"
+)
+
+// Wraps the gRPC server with its shutdown sequence:
+//  1. the health statuses flip to NOT_SERVING so the load balancers stop sending new calls
+//  2. the drain hooks end the long-lived streams, they would hold the drain until its deadline otherwise
+//  3. the running calls are drained with GracefulStop, and cancelled with Stop once the drain deadline passes
+//  4. the stop hooks release the resources used by the calls, i.e. the database pool
+type Server struct {
+	grpcServer    *grpc.Server
+	healthMonitor *healthcheck.Monitor
+	drainTimeout  time.Duration
+	drainHooks    []func()
+	stopHooks     []func() error
+}
+
+func New(grpcServer *grpc.Server, healthMonitor *healthcheck.Monitor, drainTimeout time.Duration) *Server {
+	return &Server{
+		grpcServer:    grpcServer,
+		healthMonitor: healthMonitor,
+		drainTimeout:  drainTimeout,
+	}
+}
+
+// Registers a hook run when the drain starts, while the running unary calls can still complete
+func (s *Server) OnDrain(hook func()) {
+	s.drainHooks = append(s.drainHooks, hook)
+}
+
+// Registers a hook run once no call is running anymore, the hooks run in registration order
+func (s *Server) OnStop(hook func() error) {
+	s.stopHooks = append(s.stopHooks, hook)
+}
+
+// Serves the gRPC calls until the context is done, i.e. on SIGTERM, then shuts the server down.
+// It returns once the shutdown is complete.
+func (s *Server) Run(ctx context.Context, lis net.Listener) error {
+	// Probe the dependencies before accepting calls, then keep probing in the background
+	s.healthMonitor.Start()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.grpcServer.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		// The server failed on its own, there is nothing left to drain
+		s.healthMonitor.Shutdown()
+		return errors.Join(err, s.stop())
+	case <-ctx.Done():
+	}
+
+	s.drain()
+
+	// Serve returns once the server is stopped, or right away when the server was stopped before it started serving
+	err := <-serveErr
+	if errors.Is(err, grpc.ErrServerStopped) {
+		err = nil
+	}
+
+	return errors.Join(err, s.stop())
+}
+
+func (s *Server) drain() {
+	log.Printf("shutting down, draining the running calls for up to %s", s.drainTimeout)
+
+	s.healthMonitor.Shutdown()
+
+	for _, hook := range s.drainHooks {
+		hook()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(s.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+		log.Print("all the running calls completed")
+	case <-timer.C:
+		log.Print("drain deadline passed, cancelling the running calls")
+		s.grpcServer.Stop()
+		<-drained
+	}
+}
+
+func (s *Server) stop() error {
+	var errs []error
+
+	for _, hook := range s.stopHooks {
+		if err := hook(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
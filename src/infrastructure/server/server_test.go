@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/service"
+	"github.com/lokker96/grpc_project/infrastructure/healthcheck"
+	"github.com/lokker96/grpc_project/infrastructure/interceptor"
+	"github.com/lokker96/grpc_project/infrastructure/metrics"
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	"github.com/lokker96/grpc_project/infrastructure/pubsub"
+	repository_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/repository"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testServer struct {
+	client       explore.ExploreServiceClient
+	healthServer *health.Server
+	started      chan struct{} // Receives a value when the slow call reaches the repository
+	release      chan struct{} // Closed to let the slow call complete
+	runErr       chan error
+	stopped      chan struct{} // Closed when the stop hook runs
+	cancel       context.CancelFunc
+}
+
+// Runs the explore service over an in-memory connection, its CountLikedYou call blocks until the test releases it
+func startTestServer(t *testing.T, drainTimeout time.Duration) *testServer {
+	ts := &testServer{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		runErr:  make(chan error, 1),
+		stopped: make(chan struct{}),
+	}
+
+	explorerRepository := repository_mock.NewMockExplorerRepository(t)
+	explorerRepository.On("GetUser", mock.Anything, entity.UserID(1)).Return(&entity.User{ID: 1}, nil).Maybe()
+	explorerRepository.On("GetLikesCountsByProfileId", mock.Anything, entity.UserID(1)).
+		Run(func(args mock.Arguments) {
+			ts.started <- struct{}{}
+
+			// Stop cancels the context of the running calls
+			select {
+			case <-ts.release:
+			case <-args.Get(0).(context.Context).Done():
+			}
+		}).
+		Return(map[entity.DecisionType]int64{entity.DecisionTypeLike: 2, entity.DecisionTypeSuperLike: 1}, nil).
+		Maybe()
+
+	likeHub := pubsub.NewLikeHub(pubsub.DefaultLikeHubConfig())
+
+	// The calls run as an admin, the authentication is tested with the interceptors
+	authenticator := interceptor.InsecureAuthenticator()
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.UnaryAuthentication(authenticator)),
+		grpc.StreamInterceptor(interceptor.StreamAuthentication(authenticator)),
+	)
+	explorerServer := service.NewExplorerServer(explorerRepository, likeHub, metrics.NewDecisionRecorder(prometheus.NewRegistry()))
+	explore.RegisterExploreServiceServer(grpcServer, service.NewExplorerServerV1(explorerServer))
+
+	ts.healthServer = health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, ts.healthServer)
+
+	healthMonitor := healthcheck.NewMonitor(ts.healthServer, time.Hour, time.Second)
+	healthMonitor.Register(explore.ExploreService_ServiceDesc.ServiceName)
+
+	srv := New(grpcServer, healthMonitor, drainTimeout)
+	srv.OnDrain(likeHub.Close)
+	srv.OnStop(func() error {
+		close(ts.stopped)
+		return nil
+	})
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ts.cancel = cancel
+
+	go func() {
+		ts.runErr <- srv.Run(ctx, lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+	})
+
+	ts.client = explore.NewExploreServiceClient(conn)
+
+	return ts
+}
+
+// Starts the slow call and waits until it is running in the server
+func (ts *testServer) startSlowCall(t *testing.T) (chan *explore.CountLikedYouResponse, chan error) {
+	responses := make(chan *explore.CountLikedYouResponse, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		response, err := ts.client.CountLikedYou(context.Background(), &explore.CountLikedYouRequest{RecipientUserId: "1"})
+		responses <- response
+		errs <- err
+	}()
+
+	select {
+	case <-ts.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call never reached the server")
+	}
+
+	return responses, errs
+}
+
+func (ts *testServer) waitForShutdown(t *testing.T) error {
+	select {
+	case err := <-ts.runErr:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server didn't shut down")
+		return nil
+	}
+}
+
+func Test_Run_InFlightCallsCompleteOnShutdown(t *testing.T) {
+	ts := startTestServer(t, 5*time.Second)
+
+	// A stream is open too, it must not hold the drain
+	stream, err := ts.client.WatchLikes(context.Background(), &explore.WatchLikesRequest{RecipientUserId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses, errs := ts.startSlowCall(t)
+
+	ts.cancel()
+
+	// The services are reported as not serving while the call is still running
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		response, err := ts.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{
+			Service: explore.ExploreService_ServiceDesc.ServiceName,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Status == healthpb.HealthCheckResponse_NOT_SERVING {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("the service is still serving")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The stream is ended by the drain hook
+	_, err = stream.Recv()
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+
+	// The resources are only released once the running call is complete
+	select {
+	case <-ts.stopped:
+		t.Fatal("the stop hooks ran before the running call completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(ts.release)
+
+	if err := <-errs; err != nil {
+		t.Fatalf("expected the running call to complete, got %v", err)
+	}
+
+	assert.Equal(t, (<-responses).Count, uint64(3))
+
+	if err := ts.waitForShutdown(t); err != nil {
+		t.Fatal(err)
+	}
+
+	<-ts.stopped
+}
+
+func Test_Run_RunningCallsAreCancelledAfterTheDrainDeadline(t *testing.T) {
+	ts := startTestServer(t, 50*time.Millisecond)
+
+	_, errs := ts.startSlowCall(t)
+
+	ts.cancel()
+
+	// The call is never released
+	if err := <-errs; err == nil {
+		t.Fatal("expected the running call to be cancelled")
+	}
+
+	if err := ts.waitForShutdown(t); err != nil {
+		t.Fatal(err)
+	}
+
+	<-ts.stopped
+}
+
+func Test_Run_StopHookErrorsAreReturned(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	healthMonitor := healthcheck.NewMonitor(health.NewServer(), time.Hour, time.Second)
+
+	srv := New(grpcServer, healthMonitor, time.Second)
+	srv.OnStop(func() error {
+		return errors.New("pool already closed")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := srv.Run(ctx, bufconn.Listen(1024))
+	if err == nil || err.Error() != "pool already closed" {
+		t.Fatalf("expected the stop hook error, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// Package devcert generates a local certificate authority and leaf certificates for the tests and
+// for local development. The certificates are short lived and must never be used in production.
+package devcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const validity = 30 * 24 * time.Hour
+
+type CA struct {
+	Certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	CertPEM     []byte
+}
+
+// Certificate and private key in PEM, ready to be written to files
+type Leaf struct {
+	Certificate *x509.Certificate
+	CertPEM     []byte
+	KeyPEM      []byte
+}
+
+func NewCA(commonName string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Certificate: certificate,
+		key:         key,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}, nil
+}
+
+// Issues a server certificate valid for the hosts, which can be DNS names or IP addresses
+func (ca *CA) IssueServer(hosts ...string) (*Leaf, error) {
+	template, err := newTemplate(hosts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	return ca.issue(template)
+}
+
+// Issues a client certificate, the common name identifies the client
+func (ca *CA) IssueClient(commonName string) (*Leaf, error) {
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	return ca.issue(template)
+}
+
+func (ca *CA) issue(template *x509.Certificate) (*Leaf, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Leaf{
+		Certificate: certificate,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// Writes the certificate and the key to <dir>/<name>.crt and <dir>/<name>.key
+func (l *Leaf) WriteFiles(dir string, name string) error {
+	if err := os.WriteFile(filepath.Join(dir, name+".crt"), l.CertPEM, 0o644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name+".key"), l.KeyPEM, 0o600)
+}
+
+// Writes a CA with a server certificate for localhost and a client certificate to the directory:
+// ca.crt, server.crt, server.key, client.crt and client.key
+func GenerateFiles(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	ca, err := NewCA("explore development CA")
+	if err != nil {
+		return fmt.Errorf("error on generating the CA: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), ca.CertPEM, 0o644); err != nil {
+		return err
+	}
+
+	server, err := ca.IssueServer("localhost", "127.0.0.1", "::1", "explore-server")
+	if err != nil {
+		return fmt.Errorf("error on generating the server certificate: %w", err)
+	}
+
+	if err := server.WriteFiles(dir, "server"); err != nil {
+		return err
+	}
+
+	client, err := ca.IssueClient("explore-client")
+	if err != nil {
+		return fmt.Errorf("error on generating the client certificate: %w", err)
+	}
+
+	return client.WriteFiles(dir, "client")
+}
+
+func newTemplate(commonName string) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
@@ -0,0 +1,155 @@
+// Package tlsconfig builds the TLS configurations of the server and of the clients. The certificates are
+// reloaded when their files change on disk, so they can be rotated without restarting the process.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Modification time and size of a file, a change of either means the file has been rotated
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// The reloader holds a certificate, its key and optionally a CA bundle. At most once per check interval,
+// on a handshake, it checks the files and loads them again if one of them has changed. A broken rotation,
+// i.e. a certificate written before its key, keeps the previous files in use until it is fixed.
+type Reloader struct {
+	certFile      string
+	keyFile       string
+	caFile        string // Optional
+	checkInterval time.Duration
+
+	mu          sync.Mutex
+	certificate *tls.Certificate
+	caPool      *x509.CertPool
+	versions    map[string]fileVersion
+	lastCheck   time.Time
+}
+
+// Loads the files, a failure on this first load is returned as the files are not usable at all
+func NewReloader(certFile string, keyFile string, caFile string, checkInterval time.Duration) (*Reloader, error) {
+	r := &Reloader{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		caFile:        caFile,
+		checkInterval: checkInterval,
+	}
+
+	versions, err := r.fileVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(versions); err != nil {
+		return nil, err
+	}
+
+	r.lastCheck = time.Now()
+
+	return r, nil
+}
+
+// Returns the current certificate and CA pool, reloading them if the files have changed
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastCheck) >= r.checkInterval {
+		r.lastCheck = time.Now()
+		r.reloadIfChanged()
+	}
+
+	return r.certificate, r.caPool
+}
+
+// Must be called while holding the lock
+func (r *Reloader) reloadIfChanged() {
+	versions, err := r.fileVersions()
+	if err != nil {
+		log.Printf("tls: keeping the current certificate, %s", err.Error())
+		return
+	}
+
+	changed := false
+	for file, version := range versions {
+		if r.versions[file] != version {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	if err := r.load(versions); err != nil {
+		log.Printf("tls: keeping the current certificate, %s", err.Error())
+		return
+	}
+
+	log.Printf("tls: reloaded %s", r.certFile)
+}
+
+// Must be called while holding the lock, or before the reloader is shared
+func (r *Reloader) load(versions map[string]fileVersion) error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("error on loading the certificate %s: %w", r.certFile, err)
+	}
+
+	var caPool *x509.CertPool
+
+	if r.caFile != "" {
+		caPool, err = loadCAPool(r.caFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.certificate = &certificate
+	r.caPool = caPool
+	r.versions = versions
+
+	return nil
+}
+
+func (r *Reloader) fileVersions() (map[string]fileVersion, error) {
+	versions := map[string]fileVersion{}
+
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("error on reading %s: %w", file, err)
+		}
+
+		versions[file] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return versions, nil
+}
+
+func loadCAPool(caFile string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading the ca %s: %w", caFile, err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("error, the ca file " + caFile + " doesn't contain any PEM certificate")
+	}
+
+	return caPool, nil
+}
@@ -0,0 +1,81 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+type ServerConfig struct {
+	CertFile       string
+	KeyFile        string
+	ClientCAFile   string // The clients must present a certificate signed by this CA when set
+	ReloadInterval time.Duration
+}
+
+// Builds the TLS configuration of the server, the certificate and the client CA are reloaded when they rotate
+func NewServerConfig(config ServerConfig) (*tls.Config, error) {
+	reloader, err := NewReloader(config.CertFile, config.KeyFile, config.ClientCAFile, config.ReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.NoClientCert
+	if config.ClientCAFile != "" {
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Every handshake gets the current files, the rotated ones apply to the new connections
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certificate, clientCAs := reloader.current()
+
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*certificate},
+				ClientAuth:   clientAuth,
+				ClientCAs:    clientCAs,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}, nil
+}
+
+type ClientConfig struct {
+	CAFile         string // CA the server certificate must be signed by, the system roots are used when empty
+	CertFile       string // Client certificate for mutual TLS, optional
+	KeyFile        string
+	ServerName     string // Overrides the host name checked in the server certificate
+	ReloadInterval time.Duration
+}
+
+// Builds the TLS configuration of a client, the client certificate is reloaded when it rotates
+func NewClientConfig(config ClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+
+	if config.CAFile != "" {
+		rootCAs, err := loadCAPool(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.CertFile != "" {
+		reloader, err := NewReloader(config.CertFile, config.KeyFile, "", config.ReloadInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, _ := reloader.current()
+			return certificate, nil
+		}
+	}
+
+	return tlsConfig, nil
+}
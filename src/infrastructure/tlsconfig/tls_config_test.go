@@ -0,0 +1,271 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lokker96/grpc_project/infrastructure/tlsconfig/devcert"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testPKI struct {
+	dir string
+	ca  *devcert.CA
+}
+
+// Writes a CA, a server certificate and a client certificate to a temporary directory
+func newTestPKI(t *testing.T) *testPKI {
+	pki := &testPKI{dir: t.TempDir()}
+
+	var err error
+	pki.ca, err = devcert.NewCA("test CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(pki.path("ca.crt"), pki.ca.CertPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pki.issueServer(t)
+
+	client, err := pki.ca.IssueClient("test-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.WriteFiles(pki.dir, "client"); err != nil {
+		t.Fatal(err)
+	}
+
+	return pki
+}
+
+func (pki *testPKI) path(name string) string {
+	return filepath.Join(pki.dir, name)
+}
+
+// Writes a new server certificate over the current one, its modification time is moved forward so
+// the rotation is seen even on file systems with a coarse time resolution
+func (pki *testPKI) issueServer(t *testing.T) *x509.Certificate {
+	server, err := pki.ca.IssueServer("localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.WriteFiles(pki.dir, "server"); err != nil {
+		t.Fatal(err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	for _, name := range []string{"server.crt", "server.key"} {
+		if err := os.Chtimes(pki.path(name), future, future); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return server.Certificate
+}
+
+// Serves the health service over an in-memory connection with the TLS configuration of the server
+func startServer(t *testing.T, serverConfig ServerConfig) *bufconn.Listener {
+	tlsConfig, err := NewServerConfig(serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	return lis
+}
+
+// Runs a health check, the certificate presented by the server is returned
+func check(t *testing.T, lis *bufconn.Listener, clientConfig ClientConfig) (*x509.Certificate, error) {
+	tlsConfig, err := NewClientConfig(clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := grpc.NewClient("passthrough:///localhost",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var p peer.Peer
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0], nil
+}
+
+func Test_MutualTLS(t *testing.T) {
+	pki := newTestPKI(t)
+
+	lis := startServer(t, ServerConfig{
+		CertFile:     pki.path("server.crt"),
+		KeyFile:      pki.path("server.key"),
+		ClientCAFile: pki.path("ca.crt"),
+	})
+
+	// A client certificate signed by another CA
+	otherPKI := newTestPKI(t)
+
+	testCases := []struct {
+		name         string
+		clientConfig ClientConfig
+		accepted     bool
+	}{
+		{
+			name: "Client certificate signed by the CA",
+			clientConfig: ClientConfig{
+				CAFile:   pki.path("ca.crt"),
+				CertFile: pki.path("client.crt"),
+				KeyFile:  pki.path("client.key"),
+			},
+			accepted: true,
+		},
+		{
+			name: "No client certificate",
+			clientConfig: ClientConfig{
+				CAFile: pki.path("ca.crt"),
+			},
+			accepted: false,
+		},
+		{
+			name: "Client certificate signed by another CA",
+			clientConfig: ClientConfig{
+				CAFile:   pki.path("ca.crt"),
+				CertFile: otherPKI.path("client.crt"),
+				KeyFile:  otherPKI.path("client.key"),
+			},
+			accepted: false,
+		},
+		{
+			name: "Server certificate signed by another CA",
+			clientConfig: ClientConfig{
+				CAFile:   otherPKI.path("ca.crt"),
+				CertFile: pki.path("client.crt"),
+				KeyFile:  pki.path("client.key"),
+			},
+			accepted: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := check(t, lis, testCase.clientConfig)
+
+			assert.Equal(t, err == nil, testCase.accepted)
+		})
+	}
+}
+
+func Test_TLSWithoutClientCA(t *testing.T) {
+	pki := newTestPKI(t)
+
+	lis := startServer(t, ServerConfig{
+		CertFile: pki.path("server.crt"),
+		KeyFile:  pki.path("server.key"),
+	})
+
+	// The clients don't need a certificate
+	if _, err := check(t, lis, ClientConfig{CAFile: pki.path("ca.crt")}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_ServerCertificateIsReloaded(t *testing.T) {
+	pki := newTestPKI(t)
+
+	lis := startServer(t, ServerConfig{
+		CertFile:     pki.path("server.crt"),
+		KeyFile:      pki.path("server.key"),
+		ClientCAFile: pki.path("ca.crt"),
+	})
+
+	clientConfig := ClientConfig{
+		CAFile:   pki.path("ca.crt"),
+		CertFile: pki.path("client.crt"),
+		KeyFile:  pki.path("client.key"),
+	}
+
+	if _, err := check(t, lis, clientConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := pki.issueServer(t)
+
+	// The new connections get the rotated certificate
+	certificate, err := check(t, lis, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, certificate.SerialNumber.String(), rotated.SerialNumber.String())
+}
+
+func Test_Reloader_KeepsTheCurrentCertificateOnABrokenRotation(t *testing.T) {
+	pki := newTestPKI(t)
+
+	reloader, err := NewReloader(pki.path("server.crt"), pki.path("server.key"), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, _ := reloader.current()
+
+	// The key doesn't match the certificate anymore
+	if err := os.WriteFile(pki.path("server.key"), []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, _ := reloader.current()
+	assert.Equal(t, certificate, current)
+
+	// Once the rotation is complete the new certificate is used
+	rotated := pki.issueServer(t)
+
+	certificate, _ = reloader.current()
+	assert.Equal(t, certificate.Leaf.SerialNumber.String(), rotated.SerialNumber.String())
+}
+
+func Test_NewServerConfig_FailsOnMissingFiles(t *testing.T) {
+	pki := newTestPKI(t)
+
+	_, err := NewServerConfig(ServerConfig{
+		CertFile: pki.path("server.crt"),
+		KeyFile:  pki.path("missing.key"),
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,251 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Decorates an explorer repository with a span per query, so a slow call shows which of its queries is at fault
+type ExplorerRepository struct {
+	next   repository.ExplorerRepository
+	tracer trace.Tracer
+}
+
+func NewExplorerRepository(next repository.ExplorerRepository, tracerProvider trace.TracerProvider) *ExplorerRepository {
+	return &ExplorerRepository{
+		next:   next,
+		tracer: tracerProvider.Tracer(tracerName),
+	}
+}
+
+func (r *ExplorerRepository) start(ctx context.Context, query string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, "ExplorerRepository."+query,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// Ends the span, called with defer so err holds the error returned by the query
+func end(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
+
+func (r *ExplorerRepository) CreateUser(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := r.start(ctx, "CreateUser")
+	defer end(span, &err)
+
+	return r.next.CreateUser(ctx, user)
+}
+
+func (r *ExplorerRepository) GetUser(ctx context.Context, userID entity.UserID) (_ *entity.User, err error) {
+	ctx, span := r.start(ctx, "GetUser", attribute.Int64("explore.user_id", userID.Int64()))
+	defer end(span, &err)
+
+	return r.next.GetUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) DeactivateUser(ctx context.Context, userID entity.UserID) (_ *entity.User, err error) {
+	ctx, span := r.start(ctx, "DeactivateUser", attribute.Int64("explore.user_id", userID.Int64()))
+	defer end(span, &err)
+
+	return r.next.DeactivateUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) DeleteUser(ctx context.Context, userID entity.UserID) (err error) {
+	ctx, span := r.start(ctx, "DeleteUser", attribute.Int64("explore.user_id", userID.Int64()))
+	defer end(span, &err)
+
+	return r.next.DeleteUser(ctx, userID)
+}
+
+func (r *ExplorerRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) (err error) {
+	ctx, span := r.start(ctx, "EraseUser", attribute.Int64("explore.user_id", userID.Int64()))
+	defer end(span, &err)
+
+	return r.next.EraseUser(ctx, userID, erasure)
+}
+
+func (r *ExplorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) (err error) {
+	ctx, span := r.start(ctx, "CreateDecision",
+		attribute.Int64("explore.author_id", decision.AuthorID.Int64()),
+		attribute.Int64("explore.recipient_id", decision.RecipientID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.CreateDecision(ctx, decision)
+}
+
+func (r *ExplorerRepository) ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	ctx, span := r.start(ctx, "ListDecisionsForUserId",
+		attribute.Int64("explore.user_id", userID.Int64()),
+		attribute.Bool("explore.first_page", cursor == nil),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ListDecisionsForUserId(ctx, userID, cursor, limit)
+}
+
+func (r *ExplorerRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	ctx, span := r.start(ctx, "ListLikersForRecipientId",
+		attribute.Int64("explore.recipient_id", recipientID.Int64()),
+		attribute.Bool("explore.ranked", query.Order == repository.LikersOrderRanked),
+		attribute.Bool("explore.first_page", cursor == nil),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ListLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) (_ []entity.Decision, err error) {
+	ctx, span := r.start(ctx, "ListNewLikersForRecipientId",
+		attribute.Int64("explore.recipient_id", recipientID.Int64()),
+		attribute.Bool("explore.ranked", query.Order == repository.LikersOrderRanked),
+		attribute.Bool("explore.first_page", cursor == nil),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ListNewLikersForRecipientId(ctx, recipientID, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (_ map[entity.DecisionType]int64, err error) {
+	ctx, span := r.start(ctx, "GetLikesCountsByProfileId", attribute.Int64("explore.recipient_id", profileID.Int64()))
+	defer end(span, &err)
+
+	return r.next.GetLikesCountsByProfileId(ctx, profileID)
+}
+
+func (r *ExplorerRepository) UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (_ *entity.Decision, _ *entity.Decision, err error) {
+	ctx, span := r.start(ctx, "UpsertDecision",
+		attribute.Int64("explore.author_id", authorID.Int64()),
+		attribute.Int64("explore.recipient_id", recipientID.Int64()),
+		attribute.String("explore.decision_type", string(decisionType)),
+	)
+	defer end(span, &err)
+
+	return r.next.UpsertDecision(ctx, authorID, recipientID, decisionType)
+}
+
+func (r *ExplorerRepository) UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (_ *entity.DecisionChange, _ bool, err error) {
+	ctx, span := r.start(ctx, "UndoLastDecision", attribute.Int64("explore.author_id", authorID.Int64()))
+	defer end(span, &err)
+
+	return r.next.UndoLastDecision(ctx, authorID, since)
+}
+
+func (r *ExplorerRepository) ListDecisionEvents(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int) (_ []entity.DecisionEvent, err error) {
+	ctx, span := r.start(ctx, "ListDecisionEvents",
+		attribute.Int64("explore.user_id", query.UserID.Int64()),
+		attribute.Bool("explore.first_page", cursor == nil),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ListDecisionEvents(ctx, query, cursor, limit)
+}
+
+func (r *ExplorerRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) (_ []entity.DecisionLogEvent, err error) {
+	ctx, span := r.start(ctx, "ReadDecisionLog",
+		attribute.Int64("explore.after_id", afterID),
+		attribute.Int("explore.missing_id_count", len(missingIDs)),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ReadDecisionLog(ctx, afterID, missingIDs, limit)
+}
+
+func (r *ExplorerRepository) ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) (_ []entity.OutboxEvent, err error) {
+	ctx, span := r.start(ctx, "ClaimOutboxEvents", attribute.Int("explore.limit", limit))
+	defer end(span, &err)
+
+	return r.next.ClaimOutboxEvents(ctx, limit, claimDuration)
+}
+
+func (r *ExplorerRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) (err error) {
+	ctx, span := r.start(ctx, "DeleteOutboxEvent", attribute.Int64("explore.outbox_event_id", eventID))
+	defer end(span, &err)
+
+	return r.next.DeleteOutboxEvent(ctx, eventID)
+}
+
+func (r *ExplorerRepository) RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) (err error) {
+	ctx, span := r.start(ctx, "RetryOutboxEvent", attribute.Int64("explore.outbox_event_id", eventID))
+	defer end(span, &err)
+
+	return r.next.RetryOutboxEvent(ctx, eventID, nextAttemptAt, lastError)
+}
+
+func (r *ExplorerRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (_ bool, err error) {
+	ctx, span := r.start(ctx, "FindMutualLike",
+		attribute.Int64("explore.author_id", userID.Int64()),
+		attribute.Int64("explore.recipient_id", recipientUserID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.FindMutualLike(ctx, userID, recipientUserID)
+}
+
+func (r *ExplorerRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) (_ []entity.Match, err error) {
+	ctx, span := r.start(ctx, "ListMatchesForUserId",
+		attribute.Int64("explore.user_id", userID.Int64()),
+		attribute.Bool("explore.first_page", cursor == nil),
+		attribute.Int("explore.limit", limit),
+	)
+	defer end(span, &err)
+
+	return r.next.ListMatchesForUserId(ctx, userID, cursor, limit)
+}
+
+func (r *ExplorerRepository) BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) (err error) {
+	ctx, span := r.start(ctx, "BlockUser",
+		attribute.Int64("explore.blocker_id", blockerID.Int64()),
+		attribute.Int64("explore.blocked_id", blockedID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.BlockUser(ctx, blockerID, blockedID)
+}
+
+func (r *ExplorerRepository) UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) (err error) {
+	ctx, span := r.start(ctx, "UnblockUser",
+		attribute.Int64("explore.blocker_id", blockerID.Int64()),
+		attribute.Int64("explore.blocked_id", blockedID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.UnblockUser(ctx, blockerID, blockedID)
+}
+
+func (r *ExplorerRepository) IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (_ bool, err error) {
+	ctx, span := r.start(ctx, "IsBlocked",
+		attribute.Int64("explore.user_id", userID.Int64()),
+		attribute.Int64("explore.other_user_id", otherUserID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.IsBlocked(ctx, userID, otherUserID)
+}
+
+func (r *ExplorerRepository) CreateReport(ctx context.Context, report *entity.Report) (err error) {
+	ctx, span := r.start(ctx, "CreateReport",
+		attribute.Int64("explore.reporter_id", report.ReporterID.Int64()),
+		attribute.Int64("explore.reported_id", report.ReportedID.Int64()),
+	)
+	defer end(span, &err)
+
+	return r.next.CreateReport(ctx, report)
+}
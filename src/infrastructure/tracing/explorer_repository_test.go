@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lokker96/grpc_project/domain/entity"
+	"github.com/lokker96/grpc_project/domain/repository"
+	repository_mock "github.com/lokker96/grpc_project/mocks/github.com/lokker96/grpc_project/domain/repository"
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_ExplorerRepository_StartsASpanPerQuery(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	repositoryMock := &repository_mock.MockExplorerRepository{}
+	repositoryMock.On("ListNewLikersForRecipientId", mock.Anything, entity.UserID(1), mock.Anything, mock.Anything, 11).Once().Return([]entity.Decision{}, nil)
+	repositoryMock.On("FindMutualLike", mock.Anything, entity.UserID(1), entity.UserID(2)).Once().Return(false, errors.New("connection refused"))
+
+	explorerRepository := NewExplorerRepository(repositoryMock, tracerProvider)
+
+	// The spans of the queries are children of the span of the call
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "ListNewLikedYou")
+
+	_, err := explorerRepository.ListNewLikersForRecipientId(ctx, 1, repository.LikersQuery{}, nil, 11)
+	assert.Equal(t, err, nil)
+
+	_, err = explorerRepository.FindMutualLike(ctx, 1, 2)
+	assert.Equal(t, err.Error(), "connection refused")
+
+	parent.End()
+
+	spans := spanRecorder.Ended()
+	assert.Equal(t, len(spans), 3)
+
+	testCases := []struct {
+		name               string
+		expectedStatus     codes.Code
+		expectedAttributes []attribute.KeyValue
+	}{
+		{
+			name:           "ExplorerRepository.ListNewLikersForRecipientId",
+			expectedStatus: codes.Unset,
+			expectedAttributes: []attribute.KeyValue{
+				attribute.Int("explore.recipient_id", 1),
+				attribute.Bool("explore.ranked", true),
+				attribute.Bool("explore.first_page", true),
+				attribute.Int("explore.limit", 11),
+			},
+		},
+		{
+			name:           "ExplorerRepository.FindMutualLike",
+			expectedStatus: codes.Error,
+			expectedAttributes: []attribute.KeyValue{
+				attribute.Int("explore.author_id", 1),
+				attribute.Int("explore.recipient_id", 2),
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		span := spans[i]
+
+		assert.Equal(t, span.Name(), testCase.name)
+		assert.Equal(t, span.Status().Code, testCase.expectedStatus)
+		assert.Equal(t, span.Attributes(), testCase.expectedAttributes)
+		assert.Equal(t, span.Parent().SpanID(), parent.SpanContext().SpanID())
+	}
+
+	repositoryMock.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+// Package tracing instruments the server with OpenTelemetry: the gRPC calls and the repository queries get
+// their own spans, and the W3C trace context is propagated between the clients and the server.
+package tracing
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/stats"
+)
+
+const tracerName = "github.com/lokker96/grpc_project/infrastructure/tracing"
+
+// W3C trace context and baggage, the same propagator must be used on both sides of a call
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// Stats handler of the gRPC server, it starts a span per call as a child of the caller's span when there is one.
+// The health checks aren't traced, they would drown the real calls.
+func ServerHandler(tracerProvider trace.TracerProvider) stats.Handler {
+	return otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(tracerProvider),
+		otelgrpc.WithPropagators(Propagator()),
+		otelgrpc.WithFilter(func(info *stats.RPCTagInfo) bool {
+			return !isHealthMethod(info.FullMethodName)
+		}),
+	)
+}
+
+// Stats handler of the gRPC clients, it starts a span per call and sends its trace context to the server
+func ClientHandler(tracerProvider trace.TracerProvider) stats.Handler {
+	return otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(tracerProvider),
+		otelgrpc.WithPropagators(Propagator()),
+	)
+}
+
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	"github.com/magiconair/properties/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Explore server recording the span found in the context of the last call
+type spanExploreServer struct {
+	explore.UnimplementedExploreServiceServer
+	spanContext trace.SpanContext
+}
+
+func (s *spanExploreServer) CountLikedYou(ctx context.Context, request *explore.CountLikedYouRequest) (*explore.CountLikedYouResponse, error) {
+	s.spanContext = trace.SpanContextFromContext(ctx)
+	return &explore.CountLikedYouResponse{}, nil
+}
+
+func Test_TraceContextIsPropagated(t *testing.T) {
+	clientRecorder := tracetest.NewSpanRecorder()
+	clientProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(clientRecorder))
+
+	serverRecorder := tracetest.NewSpanRecorder()
+	serverProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(serverRecorder))
+
+	exploreServer := &spanExploreServer{}
+
+	grpcServer := grpc.NewServer(grpc.StatsHandler(ServerHandler(serverProvider)))
+	explore.RegisterExploreServiceServer(grpcServer, exploreServer)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis := bufconn.Listen(1024 * 1024)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(ClientHandler(clientProvider)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, root := clientProvider.Tracer("test").Start(context.Background(), "client")
+
+	// The health checks are not traced, the other calls are
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := explore.NewExploreServiceClient(conn).CountLikedYou(ctx, &explore.CountLikedYouRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	root.End()
+
+	// The handler runs in the span of the server, which belongs to the trace of the client
+	assert.Equal(t, exploreServer.spanContext.TraceID(), root.SpanContext().TraceID())
+
+	serverSpans := serverRecorder.Ended()
+	assert.Equal(t, len(serverSpans), 1)
+	assert.Equal(t, serverSpans[0].Name(), "explore.ExploreService/CountLikedYou")
+	assert.Equal(t, serverSpans[0].SpanContext().TraceID(), root.SpanContext().TraceID())
+	assert.Equal(t, serverSpans[0].SpanKind(), trace.SpanKindServer)
+}
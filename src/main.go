@@ -1,33 +1,162 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"buf.build/go/protovalidate"
+	"github.com/lokker96/grpc_project/infrastructure/config"
 	"github.com/lokker96/grpc_project/infrastructure/container"
+	"github.com/lokker96/grpc_project/infrastructure/interceptor"
+	"github.com/lokker96/grpc_project/infrastructure/metrics"
 	ep "github.com/lokker96/grpc_project/infrastructure/proto/explore"
+	epv2 "github.com/lokker96/grpc_project/infrastructure/proto/explore/v2"
+	upv1 "github.com/lokker96/grpc_project/infrastructure/proto/user/v1"
+	"github.com/lokker96/grpc_project/infrastructure/server"
+	"github.com/lokker96/grpc_project/infrastructure/tlsconfig/devcert"
+	"github.com/lokker96/grpc_project/infrastructure/tracing"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
+	// The dev-certs subcommand writes a local CA with server and client certificates and exits, i.e. 'server dev-certs ./certs'
+	if len(os.Args) > 1 && os.Args[1] == "dev-certs" {
+		if len(os.Args) != 3 {
+			log.Fatal("usage: server dev-certs <dir>")
+		}
+
+		if err := devcert.GenerateFiles(os.Args[2]); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("development certificates written to %s", os.Args[2])
+		return
+	}
+
+	// Load the configuration from the flags, the environment and the optional YAML file
+	cfg, args, err := config.Load(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// JSON logs from now on, the standard log package included
+	logger := container.NewLogger(cfg.Log)
+
+	// The migrate subcommand manages the database schema and exits, i.e. 'server migrate up'
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(cfg, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	if len(args) > 0 {
+		log.Fatalf("unknown command %q, the commands are migrate and dev-certs", args[0])
+	}
+
 	// Building the application's container
-	c, err := container.NewContainer() // Create a new container instance
+	c, err := container.NewContainer(cfg) // Create a new container instance
 	if err != nil {
 		log.Fatal(err) // If there's an error, log it and terminate
 	}
 
-	// Setup a listener on port 9001
-	lis, err := net.Listen("tcp", ":9001")
+	authenticator, err := container.NewAuthenticator(cfg.Auth)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	serverCredentials, err := container.NewServerCredentials(cfg.TLS)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Setup a listener on the configured address
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
 	if err != nil {
 		log.Fatal("failed to listen: ", err.Error())
 	}
 
+	// The requests are checked against the buf.validate rules of the protos before reaching the handlers
+	validator, err := protovalidate.New()
+	if err != nil {
+		log.Fatal("failed to create the request validator: ", err.Error())
+	}
+
 	// Create new gRPC server and set the service responsable for responding
-	grpcServer := grpc.NewServer()
-	ep.RegisterExploreServiceServer(grpcServer, c.ExplorerServer)
+	grpcServer := grpc.NewServer(
+		grpc.Creds(serverCredentials),
+		grpc.StatsHandler(tracing.ServerHandler(c.TracerProvider)), // A span per call, child of the caller's span
+		grpc.ConnectionTimeout(cfg.Timeouts.Connection),
+		// The request ID and the logs come first so the rejected calls are logged and counted too,
+		// the recovery turns the panics into Internal errors before they reach the logs and the metrics
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryRequestID(),
+			interceptor.UnaryLogging(logger),
+			c.ServerMetrics.UnaryInterceptor(),
+			interceptor.UnaryRecovery(logger),
+			interceptor.UnaryAuthentication(authenticator),
+			interceptor.UnaryValidation(validator), // After the authentication, the anonymous callers learn nothing about the rules
+			interceptor.UnaryTimeout(cfg.Timeouts.Request),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamRequestID(),
+			interceptor.StreamLogging(logger),
+			c.ServerMetrics.StreamInterceptor(),
+			interceptor.StreamRecovery(logger),
+			interceptor.StreamAuthentication(authenticator),
+			interceptor.StreamValidation(validator),
+		),
+	)
+	ep.RegisterExploreServiceServer(grpcServer, c.ExplorerServerV1)
+	epv2.RegisterExploreServiceServer(grpcServer, c.ExplorerServer)
+	upv1.RegisterUserServiceServer(grpcServer, c.UserServer)
+	healthpb.RegisterHealthServer(grpcServer, c.HealthServer)
+	c.ServerMetrics.InitializeMetrics(grpcServer)
+
+	// Serve until SIGINT or SIGTERM, then shut down gracefully. The WatchLikes streams never complete on
+	// their own so the like hub is closed when the drain starts, the clients reconnect to another replica.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(grpcServer, c.HealthMonitor, cfg.Timeouts.Shutdown)
+	srv.OnDrain(c.LikeHub.Close)
+	srv.OnStop(c.Close)
 
-	// Listen to new gRPC calls
-	if err := grpcServer.Serve(lis); err != nil {
+	// The admin server keeps serving the metrics during the drain, it is closed last
+	if cfg.AdminListenAddress != "" {
+		adminLis, err := net.Listen("tcp", cfg.AdminListenAddress)
+		if err != nil {
+			log.Fatal("failed to listen on the admin address: ", err.Error())
+		}
+
+		adminServer := metrics.NewAdminServer(cfg.AdminListenAddress, c.MetricsRegistry)
+		go func() {
+			if err := adminServer.Serve(adminLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("admin server failed: %s", err.Error())
+			}
+		}()
+
+		srv.OnStop(adminServer.Close)
+	}
+
+	// The relay and the projection are stopped by c.Close, after the last call has completed
+	c.OutboxRelay.Start()
+
+	if c.LikesProjection != nil {
+		c.LikesProjection.Start()
+	}
+
+	if err := srv.Run(ctx, lis); err != nil {
 		log.Fatalf("Failed to serve: %s", err.Error())
 	}
+
+	log.Print("server stopped")
 }
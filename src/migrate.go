@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lokker96/grpc_project/infrastructure/config"
+	"github.com/lokker96/grpc_project/infrastructure/container"
+	"github.com/lokker96/grpc_project/infrastructure/persistence/postgres/migration"
+)
+
+const migrateUsage = "usage: server [flags] migrate [up [steps] | down [steps] | status]"
+
+// Runs the migrate subcommand: applies, reverts or shows the versioned schema migrations
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+
+	// 0 steps means all the migrations
+	steps := 0
+	if len(args) == 2 {
+		var err error
+		steps, err = strconv.Atoi(args[1])
+		if err != nil || steps <= 0 {
+			return fmt.Errorf("error, steps must be a positive number\n%s", migrateUsage)
+		}
+	}
+
+	db, err := container.NewDBConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("error on creating new db connection: %w", err)
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return fmt.Errorf("error on loading migrations: %w", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx, steps)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+	case "down":
+		// Reverting everything by accident would lose all the data, down reverts 1 migration by default
+		if steps == 0 {
+			steps = 1
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+	case "status":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("current version: %d, latest version: %d\n", version, migrator.LatestVersion())
+		for _, m := range pending {
+			fmt.Printf("pending %d_%s\n", m.Version, m.Name)
+		}
+
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -7,6 +7,10 @@ import (
 
 	entity "github.com/lokker96/grpc_project/domain/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/lokker96/grpc_project/domain/repository"
+
+	time "time"
 )
 
 // MockExplorerRepository is an autogenerated mock type for the ExplorerRepository type
@@ -22,6 +26,114 @@ func (_m *MockExplorerRepository) EXPECT() *MockExplorerRepository_Expecter {
 	return &MockExplorerRepository_Expecter{mock: &_m.Mock}
 }
 
+// BlockUser provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *MockExplorerRepository) BlockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) error); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExplorerRepository_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type MockExplorerRepository_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockerID entity.UserID
+//   - blockedID entity.UserID
+func (_e *MockExplorerRepository_Expecter) BlockUser(ctx interface{}, blockerID interface{}, blockedID interface{}) *MockExplorerRepository_BlockUser_Call {
+	return &MockExplorerRepository_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, blockerID, blockedID)}
+}
+
+func (_c *MockExplorerRepository_BlockUser_Call) Run(run func(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID)) *MockExplorerRepository_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(entity.UserID))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_BlockUser_Call) Return(_a0 error) *MockExplorerRepository_BlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExplorerRepository_BlockUser_Call) RunAndReturn(run func(context.Context, entity.UserID, entity.UserID) error) *MockExplorerRepository_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimOutboxEvents provides a mock function with given fields: ctx, limit, claimDuration
+func (_m *MockExplorerRepository) ClaimOutboxEvents(ctx context.Context, limit int, claimDuration time.Duration) ([]entity.OutboxEvent, error) {
+	ret := _m.Called(ctx, limit, claimDuration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimOutboxEvents")
+	}
+
+	var r0 []entity.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]entity.OutboxEvent, error)); ok {
+		return rf(ctx, limit, claimDuration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []entity.OutboxEvent); ok {
+		r0 = rf(ctx, limit, claimDuration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, claimDuration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ClaimOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimOutboxEvents'
+type MockExplorerRepository_ClaimOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ClaimOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - claimDuration time.Duration
+func (_e *MockExplorerRepository_Expecter) ClaimOutboxEvents(ctx interface{}, limit interface{}, claimDuration interface{}) *MockExplorerRepository_ClaimOutboxEvents_Call {
+	return &MockExplorerRepository_ClaimOutboxEvents_Call{Call: _e.mock.On("ClaimOutboxEvents", ctx, limit, claimDuration)}
+}
+
+func (_c *MockExplorerRepository_ClaimOutboxEvents_Call) Run(run func(ctx context.Context, limit int, claimDuration time.Duration)) *MockExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ClaimOutboxEvents_Call) Return(_a0 []entity.OutboxEvent, _a1 error) *MockExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ClaimOutboxEvents_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]entity.OutboxEvent, error)) *MockExplorerRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateDecision provides a mock function with given fields: ctx, decision
 func (_m *MockExplorerRepository) CreateDecision(ctx context.Context, decision *entity.Decision) error {
 	ret := _m.Called(ctx, decision)
@@ -69,6 +181,53 @@ func (_c *MockExplorerRepository_CreateDecision_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// CreateReport provides a mock function with given fields: ctx, report
+func (_m *MockExplorerRepository) CreateReport(ctx context.Context, report *entity.Report) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Report) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExplorerRepository_CreateReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReport'
+type MockExplorerRepository_CreateReport_Call struct {
+	*mock.Call
+}
+
+// CreateReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report *entity.Report
+func (_e *MockExplorerRepository_Expecter) CreateReport(ctx interface{}, report interface{}) *MockExplorerRepository_CreateReport_Call {
+	return &MockExplorerRepository_CreateReport_Call{Call: _e.mock.On("CreateReport", ctx, report)}
+}
+
+func (_c *MockExplorerRepository_CreateReport_Call) Run(run func(ctx context.Context, report *entity.Report)) *MockExplorerRepository_CreateReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Report))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_CreateReport_Call) Return(_a0 error) *MockExplorerRepository_CreateReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExplorerRepository_CreateReport_Call) RunAndReturn(run func(context.Context, *entity.Report) error) *MockExplorerRepository_CreateReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, user
 func (_m *MockExplorerRepository) CreateUser(ctx context.Context, user *entity.User) error {
 	ret := _m.Called(ctx, user)
@@ -116,137 +275,228 @@ func (_c *MockExplorerRepository_CreateUser_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// FindMutualLike provides a mock function with given fields: ctx, userID, recipientUserID
-func (_m *MockExplorerRepository) FindMutualLike(ctx context.Context, userID int, recipientUserID int) bool {
-	ret := _m.Called(ctx, userID, recipientUserID)
+// DeactivateUser provides a mock function with given fields: ctx, userID
+func (_m *MockExplorerRepository) DeactivateUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindMutualLike")
+		panic("no return value specified for DeactivateUser")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
-		r0 = rf(ctx, userID, recipientUserID)
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) *entity.User); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_DeactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateUser'
+type MockExplorerRepository_DeactivateUser_Call struct {
+	*mock.Call
+}
+
+// DeactivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID entity.UserID
+func (_e *MockExplorerRepository_Expecter) DeactivateUser(ctx interface{}, userID interface{}) *MockExplorerRepository_DeactivateUser_Call {
+	return &MockExplorerRepository_DeactivateUser_Call{Call: _e.mock.On("DeactivateUser", ctx, userID)}
+}
+
+func (_c *MockExplorerRepository_DeactivateUser_Call) Run(run func(ctx context.Context, userID entity.UserID)) *MockExplorerRepository_DeactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_DeactivateUser_Call) Return(_a0 *entity.User, _a1 error) *MockExplorerRepository_DeactivateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_DeactivateUser_Call) RunAndReturn(run func(context.Context, entity.UserID) (*entity.User, error)) *MockExplorerRepository_DeactivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOutboxEvent provides a mock function with given fields: ctx, eventID
+func (_m *MockExplorerRepository) DeleteOutboxEvent(ctx context.Context, eventID int64) error {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// MockExplorerRepository_FindMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindMutualLike'
-type MockExplorerRepository_FindMutualLike_Call struct {
+// MockExplorerRepository_DeleteOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOutboxEvent'
+type MockExplorerRepository_DeleteOutboxEvent_Call struct {
 	*mock.Call
 }
 
-// FindMutualLike is a helper method to define mock.On call
+// DeleteOutboxEvent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID int
-//   - recipientUserID int
-func (_e *MockExplorerRepository_Expecter) FindMutualLike(ctx interface{}, userID interface{}, recipientUserID interface{}) *MockExplorerRepository_FindMutualLike_Call {
-	return &MockExplorerRepository_FindMutualLike_Call{Call: _e.mock.On("FindMutualLike", ctx, userID, recipientUserID)}
+//   - eventID int64
+func (_e *MockExplorerRepository_Expecter) DeleteOutboxEvent(ctx interface{}, eventID interface{}) *MockExplorerRepository_DeleteOutboxEvent_Call {
+	return &MockExplorerRepository_DeleteOutboxEvent_Call{Call: _e.mock.On("DeleteOutboxEvent", ctx, eventID)}
 }
 
-func (_c *MockExplorerRepository_FindMutualLike_Call) Run(run func(ctx context.Context, userID int, recipientUserID int)) *MockExplorerRepository_FindMutualLike_Call {
+func (_c *MockExplorerRepository_DeleteOutboxEvent_Call) Run(run func(ctx context.Context, eventID int64)) *MockExplorerRepository_DeleteOutboxEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockExplorerRepository_FindMutualLike_Call) Return(_a0 bool) *MockExplorerRepository_FindMutualLike_Call {
+func (_c *MockExplorerRepository_DeleteOutboxEvent_Call) Return(_a0 error) *MockExplorerRepository_DeleteOutboxEvent_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExplorerRepository_FindMutualLike_Call) RunAndReturn(run func(context.Context, int, int) bool) *MockExplorerRepository_FindMutualLike_Call {
+func (_c *MockExplorerRepository_DeleteOutboxEvent_Call) RunAndReturn(run func(context.Context, int64) error) *MockExplorerRepository_DeleteOutboxEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDecisionsForRecipientId provides a mock function with given fields: ctx, userID, liked
-func (_m *MockExplorerRepository) GetDecisionsForRecipientId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error) {
-	ret := _m.Called(ctx, userID, liked)
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *MockExplorerRepository) DeleteUser(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDecisionsForRecipientId")
+		panic("no return value specified for DeleteUser")
 	}
 
-	var r0 []entity.Decision
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, *bool) ([]entity.Decision, error)); ok {
-		return rf(ctx, userID, liked)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, *bool) []entity.Decision); ok {
-		r0 = rf(ctx, userID, liked)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Decision)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExplorerRepository_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockExplorerRepository_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID entity.UserID
+func (_e *MockExplorerRepository_Expecter) DeleteUser(ctx interface{}, userID interface{}) *MockExplorerRepository_DeleteUser_Call {
+	return &MockExplorerRepository_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID)}
+}
+
+func (_c *MockExplorerRepository_DeleteUser_Call) Run(run func(ctx context.Context, userID entity.UserID)) *MockExplorerRepository_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_DeleteUser_Call) Return(_a0 error) *MockExplorerRepository_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExplorerRepository_DeleteUser_Call) RunAndReturn(run func(context.Context, entity.UserID) error) *MockExplorerRepository_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EraseUser provides a mock function with given fields: ctx, userID, erasure
+func (_m *MockExplorerRepository) EraseUser(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure) error {
+	ret := _m.Called(ctx, userID, erasure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EraseUser")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, *bool) error); ok {
-		r1 = rf(ctx, userID, liked)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, *entity.UserErasure) error); ok {
+		r0 = rf(ctx, userID, erasure)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockExplorerRepository_GetDecisionsForRecipientId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecisionsForRecipientId'
-type MockExplorerRepository_GetDecisionsForRecipientId_Call struct {
+// MockExplorerRepository_EraseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EraseUser'
+type MockExplorerRepository_EraseUser_Call struct {
 	*mock.Call
 }
 
-// GetDecisionsForRecipientId is a helper method to define mock.On call
+// EraseUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID int
-//   - liked *bool
-func (_e *MockExplorerRepository_Expecter) GetDecisionsForRecipientId(ctx interface{}, userID interface{}, liked interface{}) *MockExplorerRepository_GetDecisionsForRecipientId_Call {
-	return &MockExplorerRepository_GetDecisionsForRecipientId_Call{Call: _e.mock.On("GetDecisionsForRecipientId", ctx, userID, liked)}
+//   - userID entity.UserID
+//   - erasure *entity.UserErasure
+func (_e *MockExplorerRepository_Expecter) EraseUser(ctx interface{}, userID interface{}, erasure interface{}) *MockExplorerRepository_EraseUser_Call {
+	return &MockExplorerRepository_EraseUser_Call{Call: _e.mock.On("EraseUser", ctx, userID, erasure)}
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForRecipientId_Call) Run(run func(ctx context.Context, userID int, liked *bool)) *MockExplorerRepository_GetDecisionsForRecipientId_Call {
+func (_c *MockExplorerRepository_EraseUser_Call) Run(run func(ctx context.Context, userID entity.UserID, erasure *entity.UserErasure)) *MockExplorerRepository_EraseUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(*bool))
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(*entity.UserErasure))
 	})
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForRecipientId_Call) Return(_a0 []entity.Decision, _a1 error) *MockExplorerRepository_GetDecisionsForRecipientId_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockExplorerRepository_EraseUser_Call) Return(_a0 error) *MockExplorerRepository_EraseUser_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForRecipientId_Call) RunAndReturn(run func(context.Context, int, *bool) ([]entity.Decision, error)) *MockExplorerRepository_GetDecisionsForRecipientId_Call {
+func (_c *MockExplorerRepository_EraseUser_Call) RunAndReturn(run func(context.Context, entity.UserID, *entity.UserErasure) error) *MockExplorerRepository_EraseUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDecisionsForUserId provides a mock function with given fields: ctx, userID, liked
-func (_m *MockExplorerRepository) GetDecisionsForUserId(ctx context.Context, userID int, liked *bool) ([]entity.Decision, error) {
-	ret := _m.Called(ctx, userID, liked)
+// FindMutualLike provides a mock function with given fields: ctx, userID, recipientUserID
+func (_m *MockExplorerRepository) FindMutualLike(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID) (bool, error) {
+	ret := _m.Called(ctx, userID, recipientUserID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDecisionsForUserId")
+		panic("no return value specified for FindMutualLike")
 	}
 
-	var r0 []entity.Decision
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, *bool) ([]entity.Decision, error)); ok {
-		return rf(ctx, userID, liked)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) (bool, error)); ok {
+		return rf(ctx, userID, recipientUserID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, *bool) []entity.Decision); ok {
-		r0 = rf(ctx, userID, liked)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) bool); ok {
+		r0 = rf(ctx, userID, recipientUserID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Decision)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, *bool) error); ok {
-		r1 = rf(ctx, userID, liked)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, entity.UserID) error); ok {
+		r1 = rf(ctx, userID, recipientUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -254,128 +504,810 @@ func (_m *MockExplorerRepository) GetDecisionsForUserId(ctx context.Context, use
 	return r0, r1
 }
 
-// MockExplorerRepository_GetDecisionsForUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecisionsForUserId'
-type MockExplorerRepository_GetDecisionsForUserId_Call struct {
+// MockExplorerRepository_FindMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindMutualLike'
+type MockExplorerRepository_FindMutualLike_Call struct {
 	*mock.Call
 }
 
-// GetDecisionsForUserId is a helper method to define mock.On call
+// FindMutualLike is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID int
-//   - liked *bool
-func (_e *MockExplorerRepository_Expecter) GetDecisionsForUserId(ctx interface{}, userID interface{}, liked interface{}) *MockExplorerRepository_GetDecisionsForUserId_Call {
-	return &MockExplorerRepository_GetDecisionsForUserId_Call{Call: _e.mock.On("GetDecisionsForUserId", ctx, userID, liked)}
+//   - userID entity.UserID
+//   - recipientUserID entity.UserID
+func (_e *MockExplorerRepository_Expecter) FindMutualLike(ctx interface{}, userID interface{}, recipientUserID interface{}) *MockExplorerRepository_FindMutualLike_Call {
+	return &MockExplorerRepository_FindMutualLike_Call{Call: _e.mock.On("FindMutualLike", ctx, userID, recipientUserID)}
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForUserId_Call) Run(run func(ctx context.Context, userID int, liked *bool)) *MockExplorerRepository_GetDecisionsForUserId_Call {
+func (_c *MockExplorerRepository_FindMutualLike_Call) Run(run func(ctx context.Context, userID entity.UserID, recipientUserID entity.UserID)) *MockExplorerRepository_FindMutualLike_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(*bool))
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(entity.UserID))
 	})
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForUserId_Call) Return(_a0 []entity.Decision, _a1 error) *MockExplorerRepository_GetDecisionsForUserId_Call {
+func (_c *MockExplorerRepository_FindMutualLike_Call) Return(_a0 bool, _a1 error) *MockExplorerRepository_FindMutualLike_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetDecisionsForUserId_Call) RunAndReturn(run func(context.Context, int, *bool) ([]entity.Decision, error)) *MockExplorerRepository_GetDecisionsForUserId_Call {
+func (_c *MockExplorerRepository_FindMutualLike_Call) RunAndReturn(run func(context.Context, entity.UserID, entity.UserID) (bool, error)) *MockExplorerRepository_FindMutualLike_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLikesCountByProfileId provides a mock function with given fields: ctx, profileID
-func (_m *MockExplorerRepository) GetLikesCountByProfileId(ctx context.Context, profileID int) int64 {
+// GetLikesCountsByProfileId provides a mock function with given fields: ctx, profileID
+func (_m *MockExplorerRepository) GetLikesCountsByProfileId(ctx context.Context, profileID entity.UserID) (map[entity.DecisionType]int64, error) {
 	ret := _m.Called(ctx, profileID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLikesCountByProfileId")
+		panic("no return value specified for GetLikesCountsByProfileId")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+	var r0 map[entity.DecisionType]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) (map[entity.DecisionType]int64, error)); ok {
+		return rf(ctx, profileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) map[entity.DecisionType]int64); ok {
 		r0 = rf(ctx, profileID)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[entity.DecisionType]int64)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, profileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExplorerRepository_GetLikesCountByProfileId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikesCountByProfileId'
-type MockExplorerRepository_GetLikesCountByProfileId_Call struct {
+// MockExplorerRepository_GetLikesCountsByProfileId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikesCountsByProfileId'
+type MockExplorerRepository_GetLikesCountsByProfileId_Call struct {
 	*mock.Call
 }
 
-// GetLikesCountByProfileId is a helper method to define mock.On call
+// GetLikesCountsByProfileId is a helper method to define mock.On call
 //   - ctx context.Context
-//   - profileID int
-func (_e *MockExplorerRepository_Expecter) GetLikesCountByProfileId(ctx interface{}, profileID interface{}) *MockExplorerRepository_GetLikesCountByProfileId_Call {
-	return &MockExplorerRepository_GetLikesCountByProfileId_Call{Call: _e.mock.On("GetLikesCountByProfileId", ctx, profileID)}
+//   - profileID entity.UserID
+func (_e *MockExplorerRepository_Expecter) GetLikesCountsByProfileId(ctx interface{}, profileID interface{}) *MockExplorerRepository_GetLikesCountsByProfileId_Call {
+	return &MockExplorerRepository_GetLikesCountsByProfileId_Call{Call: _e.mock.On("GetLikesCountsByProfileId", ctx, profileID)}
 }
 
-func (_c *MockExplorerRepository_GetLikesCountByProfileId_Call) Run(run func(ctx context.Context, profileID int)) *MockExplorerRepository_GetLikesCountByProfileId_Call {
+func (_c *MockExplorerRepository_GetLikesCountsByProfileId_Call) Run(run func(ctx context.Context, profileID entity.UserID)) *MockExplorerRepository_GetLikesCountsByProfileId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int))
+		run(args[0].(context.Context), args[1].(entity.UserID))
 	})
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetLikesCountByProfileId_Call) Return(_a0 int64) *MockExplorerRepository_GetLikesCountByProfileId_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExplorerRepository_GetLikesCountsByProfileId_Call) Return(_a0 map[entity.DecisionType]int64, _a1 error) *MockExplorerRepository_GetLikesCountsByProfileId_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockExplorerRepository_GetLikesCountByProfileId_Call) RunAndReturn(run func(context.Context, int) int64) *MockExplorerRepository_GetLikesCountByProfileId_Call {
+func (_c *MockExplorerRepository_GetLikesCountsByProfileId_Call) RunAndReturn(run func(context.Context, entity.UserID) (map[entity.DecisionType]int64, error)) *MockExplorerRepository_GetLikesCountsByProfileId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateDecision provides a mock function with given fields: ctx, userID, recipientUserId, liked
-func (_m *MockExplorerRepository) UpdateDecision(ctx context.Context, userID int, recipientUserId int, liked bool) error {
-	ret := _m.Called(ctx, userID, recipientUserId, liked)
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *MockExplorerRepository) GetUser(ctx context.Context, userID entity.UserID) (*entity.User, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateDecision")
+		panic("no return value specified for GetUser")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool) error); ok {
-		r0 = rf(ctx, userID, recipientUserId, liked)
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) (*entity.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) *entity.User); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockExplorerRepository_UpdateDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDecision'
-type MockExplorerRepository_UpdateDecision_Call struct {
+// MockExplorerRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockExplorerRepository_GetUser_Call struct {
 	*mock.Call
 }
 
-// UpdateDecision is a helper method to define mock.On call
+// GetUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID int
-//   - recipientUserId int
-//   - liked bool
-func (_e *MockExplorerRepository_Expecter) UpdateDecision(ctx interface{}, userID interface{}, recipientUserId interface{}, liked interface{}) *MockExplorerRepository_UpdateDecision_Call {
-	return &MockExplorerRepository_UpdateDecision_Call{Call: _e.mock.On("UpdateDecision", ctx, userID, recipientUserId, liked)}
+//   - userID entity.UserID
+func (_e *MockExplorerRepository_Expecter) GetUser(ctx interface{}, userID interface{}) *MockExplorerRepository_GetUser_Call {
+	return &MockExplorerRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
 }
 
-func (_c *MockExplorerRepository_UpdateDecision_Call) Run(run func(ctx context.Context, userID int, recipientUserId int, liked bool)) *MockExplorerRepository_UpdateDecision_Call {
+func (_c *MockExplorerRepository_GetUser_Call) Run(run func(ctx context.Context, userID entity.UserID)) *MockExplorerRepository_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(bool))
+		run(args[0].(context.Context), args[1].(entity.UserID))
 	})
 	return _c
 }
 
-func (_c *MockExplorerRepository_UpdateDecision_Call) Return(_a0 error) *MockExplorerRepository_UpdateDecision_Call {
-	_c.Call.Return(_a0)
+func (_c *MockExplorerRepository_GetUser_Call) Return(_a0 *entity.User, _a1 error) *MockExplorerRepository_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_GetUser_Call) RunAndReturn(run func(context.Context, entity.UserID) (*entity.User, error)) *MockExplorerRepository_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlocked provides a mock function with given fields: ctx, userID, otherUserID
+func (_m *MockExplorerRepository) IsBlocked(ctx context.Context, userID entity.UserID, otherUserID entity.UserID) (bool, error) {
+	ret := _m.Called(ctx, userID, otherUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) (bool, error)); ok {
+		return rf(ctx, userID, otherUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) bool); ok {
+		r0 = rf(ctx, userID, otherUserID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, entity.UserID) error); ok {
+		r1 = rf(ctx, userID, otherUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_IsBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlocked'
+type MockExplorerRepository_IsBlocked_Call struct {
+	*mock.Call
+}
+
+// IsBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID entity.UserID
+//   - otherUserID entity.UserID
+func (_e *MockExplorerRepository_Expecter) IsBlocked(ctx interface{}, userID interface{}, otherUserID interface{}) *MockExplorerRepository_IsBlocked_Call {
+	return &MockExplorerRepository_IsBlocked_Call{Call: _e.mock.On("IsBlocked", ctx, userID, otherUserID)}
+}
+
+func (_c *MockExplorerRepository_IsBlocked_Call) Run(run func(ctx context.Context, userID entity.UserID, otherUserID entity.UserID)) *MockExplorerRepository_IsBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(entity.UserID))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_IsBlocked_Call) Return(_a0 bool, _a1 error) *MockExplorerRepository_IsBlocked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_IsBlocked_Call) RunAndReturn(run func(context.Context, entity.UserID, entity.UserID) (bool, error)) *MockExplorerRepository_IsBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDecisionEvents provides a mock function with given fields: ctx, query, cursor, limit
+func (_m *MockExplorerRepository) ListDecisionEvents(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int) ([]entity.DecisionEvent, error) {
+	ret := _m.Called(ctx, query, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDecisionEvents")
+	}
+
+	var r0 []entity.DecisionEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.DecisionEventsQuery, *repository.DecisionEventCursor, int) ([]entity.DecisionEvent, error)); ok {
+		return rf(ctx, query, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.DecisionEventsQuery, *repository.DecisionEventCursor, int) []entity.DecisionEvent); ok {
+		r0 = rf(ctx, query, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DecisionEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.DecisionEventsQuery, *repository.DecisionEventCursor, int) error); ok {
+		r1 = rf(ctx, query, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ListDecisionEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDecisionEvents'
+type MockExplorerRepository_ListDecisionEvents_Call struct {
+	*mock.Call
+}
+
+// ListDecisionEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query repository.DecisionEventsQuery
+//   - cursor *repository.DecisionEventCursor
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ListDecisionEvents(ctx interface{}, query interface{}, cursor interface{}, limit interface{}) *MockExplorerRepository_ListDecisionEvents_Call {
+	return &MockExplorerRepository_ListDecisionEvents_Call{Call: _e.mock.On("ListDecisionEvents", ctx, query, cursor, limit)}
+}
+
+func (_c *MockExplorerRepository_ListDecisionEvents_Call) Run(run func(ctx context.Context, query repository.DecisionEventsQuery, cursor *repository.DecisionEventCursor, limit int)) *MockExplorerRepository_ListDecisionEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DecisionEventsQuery), args[2].(*repository.DecisionEventCursor), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListDecisionEvents_Call) Return(_a0 []entity.DecisionEvent, _a1 error) *MockExplorerRepository_ListDecisionEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListDecisionEvents_Call) RunAndReturn(run func(context.Context, repository.DecisionEventsQuery, *repository.DecisionEventCursor, int) ([]entity.DecisionEvent, error)) *MockExplorerRepository_ListDecisionEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDecisionsForUserId provides a mock function with given fields: ctx, userID, cursor, limit
+func (_m *MockExplorerRepository) ListDecisionsForUserId(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	ret := _m.Called(ctx, userID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDecisionsForUserId")
+	}
+
+	var r0 []entity.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, *repository.DecisionCursor, int) ([]entity.Decision, error)); ok {
+		return rf(ctx, userID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, *repository.DecisionCursor, int) []entity.Decision); ok {
+		r0 = rf(ctx, userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, *repository.DecisionCursor, int) error); ok {
+		r1 = rf(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ListDecisionsForUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDecisionsForUserId'
+type MockExplorerRepository_ListDecisionsForUserId_Call struct {
+	*mock.Call
+}
+
+// ListDecisionsForUserId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID entity.UserID
+//   - cursor *repository.DecisionCursor
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ListDecisionsForUserId(ctx interface{}, userID interface{}, cursor interface{}, limit interface{}) *MockExplorerRepository_ListDecisionsForUserId_Call {
+	return &MockExplorerRepository_ListDecisionsForUserId_Call{Call: _e.mock.On("ListDecisionsForUserId", ctx, userID, cursor, limit)}
+}
+
+func (_c *MockExplorerRepository_ListDecisionsForUserId_Call) Run(run func(ctx context.Context, userID entity.UserID, cursor *repository.DecisionCursor, limit int)) *MockExplorerRepository_ListDecisionsForUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(*repository.DecisionCursor), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListDecisionsForUserId_Call) Return(_a0 []entity.Decision, _a1 error) *MockExplorerRepository_ListDecisionsForUserId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListDecisionsForUserId_Call) RunAndReturn(run func(context.Context, entity.UserID, *repository.DecisionCursor, int) ([]entity.Decision, error)) *MockExplorerRepository_ListDecisionsForUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLikersForRecipientId provides a mock function with given fields: ctx, recipientID, query, cursor, limit
+func (_m *MockExplorerRepository) ListLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	ret := _m.Called(ctx, recipientID, query, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLikersForRecipientId")
+	}
+
+	var r0 []entity.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) ([]entity.Decision, error)); ok {
+		return rf(ctx, recipientID, query, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) []entity.Decision); ok {
+		r0 = rf(ctx, recipientID, query, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) error); ok {
+		r1 = rf(ctx, recipientID, query, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ListLikersForRecipientId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLikersForRecipientId'
+type MockExplorerRepository_ListLikersForRecipientId_Call struct {
+	*mock.Call
+}
+
+// ListLikersForRecipientId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID entity.UserID
+//   - query repository.LikersQuery
+//   - cursor *repository.DecisionCursor
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ListLikersForRecipientId(ctx interface{}, recipientID interface{}, query interface{}, cursor interface{}, limit interface{}) *MockExplorerRepository_ListLikersForRecipientId_Call {
+	return &MockExplorerRepository_ListLikersForRecipientId_Call{Call: _e.mock.On("ListLikersForRecipientId", ctx, recipientID, query, cursor, limit)}
+}
+
+func (_c *MockExplorerRepository_ListLikersForRecipientId_Call) Run(run func(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int)) *MockExplorerRepository_ListLikersForRecipientId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(repository.LikersQuery), args[3].(*repository.DecisionCursor), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListLikersForRecipientId_Call) Return(_a0 []entity.Decision, _a1 error) *MockExplorerRepository_ListLikersForRecipientId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListLikersForRecipientId_Call) RunAndReturn(run func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) ([]entity.Decision, error)) *MockExplorerRepository_ListLikersForRecipientId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMatchesForUserId provides a mock function with given fields: ctx, userID, cursor, limit
+func (_m *MockExplorerRepository) ListMatchesForUserId(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int) ([]entity.Match, error) {
+	ret := _m.Called(ctx, userID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMatchesForUserId")
+	}
+
+	var r0 []entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, *repository.MatchCursor, int) ([]entity.Match, error)); ok {
+		return rf(ctx, userID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, *repository.MatchCursor, int) []entity.Match); ok {
+		r0 = rf(ctx, userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, *repository.MatchCursor, int) error); ok {
+		r1 = rf(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ListMatchesForUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatchesForUserId'
+type MockExplorerRepository_ListMatchesForUserId_Call struct {
+	*mock.Call
+}
+
+// ListMatchesForUserId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID entity.UserID
+//   - cursor *repository.MatchCursor
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ListMatchesForUserId(ctx interface{}, userID interface{}, cursor interface{}, limit interface{}) *MockExplorerRepository_ListMatchesForUserId_Call {
+	return &MockExplorerRepository_ListMatchesForUserId_Call{Call: _e.mock.On("ListMatchesForUserId", ctx, userID, cursor, limit)}
+}
+
+func (_c *MockExplorerRepository_ListMatchesForUserId_Call) Run(run func(ctx context.Context, userID entity.UserID, cursor *repository.MatchCursor, limit int)) *MockExplorerRepository_ListMatchesForUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(*repository.MatchCursor), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListMatchesForUserId_Call) Return(_a0 []entity.Match, _a1 error) *MockExplorerRepository_ListMatchesForUserId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListMatchesForUserId_Call) RunAndReturn(run func(context.Context, entity.UserID, *repository.MatchCursor, int) ([]entity.Match, error)) *MockExplorerRepository_ListMatchesForUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNewLikersForRecipientId provides a mock function with given fields: ctx, recipientID, query, cursor, limit
+func (_m *MockExplorerRepository) ListNewLikersForRecipientId(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int) ([]entity.Decision, error) {
+	ret := _m.Called(ctx, recipientID, query, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNewLikersForRecipientId")
+	}
+
+	var r0 []entity.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) ([]entity.Decision, error)); ok {
+		return rf(ctx, recipientID, query, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) []entity.Decision); ok {
+		r0 = rf(ctx, recipientID, query, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) error); ok {
+		r1 = rf(ctx, recipientID, query, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ListNewLikersForRecipientId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewLikersForRecipientId'
+type MockExplorerRepository_ListNewLikersForRecipientId_Call struct {
+	*mock.Call
+}
+
+// ListNewLikersForRecipientId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID entity.UserID
+//   - query repository.LikersQuery
+//   - cursor *repository.DecisionCursor
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ListNewLikersForRecipientId(ctx interface{}, recipientID interface{}, query interface{}, cursor interface{}, limit interface{}) *MockExplorerRepository_ListNewLikersForRecipientId_Call {
+	return &MockExplorerRepository_ListNewLikersForRecipientId_Call{Call: _e.mock.On("ListNewLikersForRecipientId", ctx, recipientID, query, cursor, limit)}
+}
+
+func (_c *MockExplorerRepository_ListNewLikersForRecipientId_Call) Run(run func(ctx context.Context, recipientID entity.UserID, query repository.LikersQuery, cursor *repository.DecisionCursor, limit int)) *MockExplorerRepository_ListNewLikersForRecipientId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(repository.LikersQuery), args[3].(*repository.DecisionCursor), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListNewLikersForRecipientId_Call) Return(_a0 []entity.Decision, _a1 error) *MockExplorerRepository_ListNewLikersForRecipientId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ListNewLikersForRecipientId_Call) RunAndReturn(run func(context.Context, entity.UserID, repository.LikersQuery, *repository.DecisionCursor, int) ([]entity.Decision, error)) *MockExplorerRepository_ListNewLikersForRecipientId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadDecisionLog provides a mock function with given fields: ctx, afterID, missingIDs, limit
+func (_m *MockExplorerRepository) ReadDecisionLog(ctx context.Context, afterID int64, missingIDs []int64, limit int) ([]entity.DecisionLogEvent, error) {
+	ret := _m.Called(ctx, afterID, missingIDs, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadDecisionLog")
+	}
+
+	var r0 []entity.DecisionLogEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, int) ([]entity.DecisionLogEvent, error)); ok {
+		return rf(ctx, afterID, missingIDs, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, int) []entity.DecisionLogEvent); ok {
+		r0 = rf(ctx, afterID, missingIDs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DecisionLogEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []int64, int) error); ok {
+		r1 = rf(ctx, afterID, missingIDs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExplorerRepository_ReadDecisionLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadDecisionLog'
+type MockExplorerRepository_ReadDecisionLog_Call struct {
+	*mock.Call
+}
+
+// ReadDecisionLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int64
+//   - missingIDs []int64
+//   - limit int
+func (_e *MockExplorerRepository_Expecter) ReadDecisionLog(ctx interface{}, afterID interface{}, missingIDs interface{}, limit interface{}) *MockExplorerRepository_ReadDecisionLog_Call {
+	return &MockExplorerRepository_ReadDecisionLog_Call{Call: _e.mock.On("ReadDecisionLog", ctx, afterID, missingIDs, limit)}
+}
+
+func (_c *MockExplorerRepository_ReadDecisionLog_Call) Run(run func(ctx context.Context, afterID int64, missingIDs []int64, limit int)) *MockExplorerRepository_ReadDecisionLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_ReadDecisionLog_Call) Return(_a0 []entity.DecisionLogEvent, _a1 error) *MockExplorerRepository_ReadDecisionLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExplorerRepository_ReadDecisionLog_Call) RunAndReturn(run func(context.Context, int64, []int64, int) ([]entity.DecisionLogEvent, error)) *MockExplorerRepository_ReadDecisionLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryOutboxEvent provides a mock function with given fields: ctx, eventID, nextAttemptAt, lastError
+func (_m *MockExplorerRepository) RetryOutboxEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error {
+	ret := _m.Called(ctx, eventID, nextAttemptAt, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, string) error); ok {
+		r0 = rf(ctx, eventID, nextAttemptAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExplorerRepository_RetryOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryOutboxEvent'
+type MockExplorerRepository_RetryOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// RetryOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID int64
+//   - nextAttemptAt time.Time
+//   - lastError string
+func (_e *MockExplorerRepository_Expecter) RetryOutboxEvent(ctx interface{}, eventID interface{}, nextAttemptAt interface{}, lastError interface{}) *MockExplorerRepository_RetryOutboxEvent_Call {
+	return &MockExplorerRepository_RetryOutboxEvent_Call{Call: _e.mock.On("RetryOutboxEvent", ctx, eventID, nextAttemptAt, lastError)}
+}
+
+func (_c *MockExplorerRepository_RetryOutboxEvent_Call) Run(run func(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string)) *MockExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_RetryOutboxEvent_Call) Return(_a0 error) *MockExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExplorerRepository_RetryOutboxEvent_Call) RunAndReturn(run func(context.Context, int64, time.Time, string) error) *MockExplorerRepository_RetryOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *MockExplorerRepository) UnblockUser(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID) error {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID) error); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExplorerRepository_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type MockExplorerRepository_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockerID entity.UserID
+//   - blockedID entity.UserID
+func (_e *MockExplorerRepository_Expecter) UnblockUser(ctx interface{}, blockerID interface{}, blockedID interface{}) *MockExplorerRepository_UnblockUser_Call {
+	return &MockExplorerRepository_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, blockerID, blockedID)}
+}
+
+func (_c *MockExplorerRepository_UnblockUser_Call) Run(run func(ctx context.Context, blockerID entity.UserID, blockedID entity.UserID)) *MockExplorerRepository_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(entity.UserID))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_UnblockUser_Call) Return(_a0 error) *MockExplorerRepository_UnblockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExplorerRepository_UnblockUser_Call) RunAndReturn(run func(context.Context, entity.UserID, entity.UserID) error) *MockExplorerRepository_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndoLastDecision provides a mock function with given fields: ctx, authorID, since
+func (_m *MockExplorerRepository) UndoLastDecision(ctx context.Context, authorID entity.UserID, since time.Time) (*entity.DecisionChange, bool, error) {
+	ret := _m.Called(ctx, authorID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoLastDecision")
+	}
+
+	var r0 *entity.DecisionChange
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, time.Time) (*entity.DecisionChange, bool, error)); ok {
+		return rf(ctx, authorID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, time.Time) *entity.DecisionChange); ok {
+		r0 = rf(ctx, authorID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DecisionChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, time.Time) bool); ok {
+		r1 = rf(ctx, authorID, since)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.UserID, time.Time) error); ok {
+		r2 = rf(ctx, authorID, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockExplorerRepository_UndoLastDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndoLastDecision'
+type MockExplorerRepository_UndoLastDecision_Call struct {
+	*mock.Call
+}
+
+// UndoLastDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID entity.UserID
+//   - since time.Time
+func (_e *MockExplorerRepository_Expecter) UndoLastDecision(ctx interface{}, authorID interface{}, since interface{}) *MockExplorerRepository_UndoLastDecision_Call {
+	return &MockExplorerRepository_UndoLastDecision_Call{Call: _e.mock.On("UndoLastDecision", ctx, authorID, since)}
+}
+
+func (_c *MockExplorerRepository_UndoLastDecision_Call) Run(run func(ctx context.Context, authorID entity.UserID, since time.Time)) *MockExplorerRepository_UndoLastDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_UndoLastDecision_Call) Return(_a0 *entity.DecisionChange, _a1 bool, _a2 error) *MockExplorerRepository_UndoLastDecision_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockExplorerRepository_UndoLastDecision_Call) RunAndReturn(run func(context.Context, entity.UserID, time.Time) (*entity.DecisionChange, bool, error)) *MockExplorerRepository_UndoLastDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertDecision provides a mock function with given fields: ctx, authorID, recipientID, decisionType
+func (_m *MockExplorerRepository) UpsertDecision(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType) (*entity.Decision, *entity.Decision, error) {
+	ret := _m.Called(ctx, authorID, recipientID, decisionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertDecision")
+	}
+
+	var r0 *entity.Decision
+	var r1 *entity.Decision
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID, entity.DecisionType) (*entity.Decision, *entity.Decision, error)); ok {
+		return rf(ctx, authorID, recipientID, decisionType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.UserID, entity.DecisionType) *entity.Decision); ok {
+		r0 = rf(ctx, authorID, recipientID, decisionType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID, entity.UserID, entity.DecisionType) *entity.Decision); ok {
+		r1 = rf(ctx, authorID, recipientID, decisionType)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.UserID, entity.UserID, entity.DecisionType) error); ok {
+		r2 = rf(ctx, authorID, recipientID, decisionType)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockExplorerRepository_UpsertDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertDecision'
+type MockExplorerRepository_UpsertDecision_Call struct {
+	*mock.Call
+}
+
+// UpsertDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID entity.UserID
+//   - recipientID entity.UserID
+//   - decisionType entity.DecisionType
+func (_e *MockExplorerRepository_Expecter) UpsertDecision(ctx interface{}, authorID interface{}, recipientID interface{}, decisionType interface{}) *MockExplorerRepository_UpsertDecision_Call {
+	return &MockExplorerRepository_UpsertDecision_Call{Call: _e.mock.On("UpsertDecision", ctx, authorID, recipientID, decisionType)}
+}
+
+func (_c *MockExplorerRepository_UpsertDecision_Call) Run(run func(ctx context.Context, authorID entity.UserID, recipientID entity.UserID, decisionType entity.DecisionType)) *MockExplorerRepository_UpsertDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.UserID), args[2].(entity.UserID), args[3].(entity.DecisionType))
+	})
+	return _c
+}
+
+func (_c *MockExplorerRepository_UpsertDecision_Call) Return(_a0 *entity.Decision, _a1 *entity.Decision, _a2 error) *MockExplorerRepository_UpsertDecision_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockExplorerRepository_UpdateDecision_Call) RunAndReturn(run func(context.Context, int, int, bool) error) *MockExplorerRepository_UpdateDecision_Call {
+func (_c *MockExplorerRepository_UpsertDecision_Call) RunAndReturn(run func(context.Context, entity.UserID, entity.UserID, entity.DecisionType) (*entity.Decision, *entity.Decision, error)) *MockExplorerRepository_UpsertDecision_Call {
 	_c.Call.Return(run)
 	return _c
 }